@@ -0,0 +1,188 @@
+package roamer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+// countingReader counts bytes read from request body.
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+// Read reads from underlying reader and counts read bytes.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+
+	return n, err
+}
+
+// limitedReader fails reading of request body exceeding limit.
+type limitedReader struct {
+	io.ReadCloser
+	left     int64
+	exceeded bool
+}
+
+// Read reads from underlying reader until limit is exceeded.
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.exceeded {
+		return 0, errors.WithStack(rerr.RequestTooLarge)
+	}
+
+	// read one byte over the limit to find out whether body exceeds it.
+	if int64(len(p)) > l.left+1 {
+		p = p[:l.left+1]
+	}
+
+	n, err := l.ReadCloser.Read(p)
+	if int64(n) > l.left {
+		n = int(l.left)
+		l.left = 0
+		l.exceeded = true
+
+		return n, errors.WithStack(rerr.RequestTooLarge)
+	}
+
+	l.left -= int64(n)
+
+	return n, err
+}
+
+// contextReader aborts reading of request body when context is done.
+//
+// Underlying body is closed once context is done to unblock pending read,
+// reader which does not unblock on close still observes cancellation only between reads.
+type contextReader struct {
+	io.ReadCloser
+	ctx  context.Context
+	stop func() bool
+	err  error
+}
+
+// newContextReader returns reader of body which is closed when context is done.
+func newContextReader(ctx context.Context, body io.ReadCloser) *contextReader {
+	return &contextReader{
+		ReadCloser: body,
+		ctx:        ctx,
+		stop: context.AfterFunc(ctx, func() {
+			_ = body.Close()
+		}),
+	}
+}
+
+// Read reads from underlying reader checking context before and after reading.
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		c.err = err
+		return 0, err
+	}
+
+	n, err := c.ReadCloser.Read(p)
+	if ctxErr := c.ctx.Err(); ctxErr != nil {
+		c.err = ctxErr
+		return n, ctxErr
+	}
+
+	return n, err
+}
+
+// pooledBody preserved request body in a buffer of pool.
+type pooledBody struct {
+	*bytes.Reader
+	buf  *bytes.Buffer
+	pool *sync.Pool
+}
+
+// Close resets buffer and returns it to the pool, repeated calls do nothing.
+func (p *pooledBody) Close() error {
+	if p.buf == nil {
+		return nil
+	}
+
+	p.Reader.Reset(nil)
+	p.buf.Reset()
+	p.pool.Put(p.buf)
+	p.buf = nil
+
+	return nil
+}
+
+// ReleaseBody returns buffer of body preserved by WithPreserveBodyPool to the pool,
+// it does nothing for other bodies. Body must not be read after release.
+//
+// net/http closes only the original body of request, so handlers which are not wrapped
+// by Middleware or SliceMiddleware call it after they are done with request.
+func ReleaseBody(req *http.Request) {
+	if body, ok := req.Body.(*pooledBody); ok {
+		_ = body.Close()
+	}
+}
+
+// bodyBuffer returns empty buffer of pool or a new buffer.
+func bodyBuffer(pool *sync.Pool) *bytes.Buffer {
+	if buf, ok := pool.Get().(*bytes.Buffer); ok && buf != nil {
+		buf.Reset()
+		return buf
+	}
+
+	return new(bytes.Buffer)
+}
+
+const (
+	// EncodingGzip gzip content encoding.
+	EncodingGzip = "gzip"
+	// EncodingDeflate deflate content encoding.
+	EncodingDeflate = "deflate"
+)
+
+// decompressingReader decompresses request body.
+type decompressingReader struct {
+	io.Reader
+	body         io.ReadCloser
+	decompressor io.Closer
+}
+
+// Close closes decompressor and request body.
+func (d *decompressingReader) Close() error {
+	if err := d.decompressor.Close(); err != nil {
+		_ = d.body.Close()
+		return err
+	}
+
+	return d.body.Close()
+}
+
+// newDecompressingReader returns reader decompressing body according to content encoding,
+// false is returned for unsupported encodings.
+func newDecompressingReader(contentEncoding string, body io.ReadCloser) (*decompressingReader, bool, error) {
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case EncodingGzip, "x-gzip":
+		zr, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, true, errors.Wrap(err, "gzip")
+		}
+
+		return &decompressingReader{Reader: zr, body: body, decompressor: zr}, true, nil
+	case EncodingDeflate:
+		zr, err := zlib.NewReader(body)
+		if err != nil {
+			return nil, true, errors.Wrap(err, "deflate")
+		}
+
+		return &decompressingReader{Reader: zr, body: body, decompressor: zr}, true, nil
+	}
+
+	return nil, false, nil
+}
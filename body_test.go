@@ -0,0 +1,591 @@
+package roamer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"crypto/sha1" //nolint:gosec // checksum
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slipros/roamer/decoder"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoamer_Parse_RequestSizeLimit(t *testing.T) {
+	type Data struct {
+		Name string `json:"name"`
+	}
+
+	const body = `{"name":"test"}`
+
+	tests := []struct {
+		name          string
+		limit         int64
+		preserveBody  bool
+		contentLength bool
+		wantErr       error
+	}{
+		{
+			name:  "under limit",
+			limit: int64(len(body)),
+		},
+		{
+			name:    "over limit",
+			limit:   int64(len(body)) - 1,
+			wantErr: rerr.RequestTooLarge,
+		},
+		{
+			name:          "over limit by content length",
+			limit:         int64(len(body)) - 1,
+			contentLength: true,
+			wantErr:       rerr.RequestTooLarge,
+		},
+		{
+			name:         "under limit with preserved body",
+			limit:        int64(len(body)),
+			preserveBody: true,
+		},
+		{
+			name:         "over limit with preserved body",
+			limit:        int64(len(body)) - 1,
+			preserveBody: true,
+			wantErr:      rerr.RequestTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "test.com", io.NopCloser(strings.NewReader(body)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+			req.ContentLength = -1
+			if tt.contentLength {
+				req.ContentLength = int64(len(body))
+			}
+
+			opts := []OptionsFunc{WithDecoders(decoder.NewJSON()), WithRequestSizeLimit(tt.limit)}
+			if tt.preserveBody {
+				opts = append(opts, WithPreserveBody())
+			}
+
+			var d Data
+			err = NewRoamer(opts...).Parse(req, &d)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, Data{Name: "test"}, d)
+
+			if tt.preserveBody {
+				data, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				require.Equal(t, body, string(data))
+			}
+		})
+	}
+}
+
+func TestRoamer_Parse_PreserveBody(t *testing.T) {
+	type Data struct {
+		Name       string `json:"name"`
+		BodyLength int64  `meta:"body_length"`
+	}
+
+	const body = `{"name":"test"}`
+
+	req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+	r := NewRoamer(
+		WithDecoders(decoder.NewJSON()),
+		WithParsers(parser.NewMeta()),
+		WithPreserveBody(),
+	)
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Name: "test", BodyLength: int64(len(body))}, d)
+
+	data, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, body, string(data))
+}
+
+func TestRoamer_Parse_PreserveBodyPool(t *testing.T) {
+	type Data struct {
+		Name       string `json:"name"`
+		BodyLength int64  `meta:"body_length"`
+	}
+
+	const body = `{"name":"test"}`
+
+	newRequest := func(t *testing.T) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+		return req
+	}
+
+	var pool sync.Pool
+	pool.Put(bytes.NewBufferString("stale"))
+
+	r := NewRoamer(
+		WithDecoders(decoder.NewJSON()),
+		WithParsers(parser.NewMeta()),
+		WithPreserveBodyPool(&pool),
+	)
+
+	req := newRequest(t)
+
+	var d Data
+	err := r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Name: "test", BodyLength: int64(len(body))}, d)
+
+	pooled, ok := req.Body.(*pooledBody)
+	require.True(t, ok)
+
+	buf := pooled.buf
+	require.Equal(t, body, buf.String(), "buffer of pool is reset before use")
+
+	data, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, body, string(data))
+
+	require.NoError(t, req.Body.Close())
+	require.NoError(t, req.Body.Close())
+	require.Zero(t, buf.Len(), "buffer is reset after close")
+	require.Nil(t, pooled.buf)
+
+	// empty pool without New function.
+	req = newRequest(t)
+
+	d = Data{}
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, "test", d.Name)
+
+	data, err = io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, body, string(data))
+}
+
+func TestMiddleware_ReleaseBody(t *testing.T) {
+	type Data struct {
+		Name string `json:"name"`
+	}
+
+	const body = `{"name":"test"}`
+
+	var (
+		buffers []*bytes.Buffer
+		bodies  []*pooledBody
+	)
+
+	newRoamer := func() *Roamer {
+		pool := &sync.Pool{
+			New: func() any {
+				buf := new(bytes.Buffer)
+				buffers = append(buffers, buf)
+				return buf
+			},
+		}
+
+		return NewRoamer(WithDecoders(decoder.NewJSON()), WithPreserveBodyPool(pool))
+	}
+
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		pooled, ok := req.Body.(*pooledBody)
+		require.True(t, ok)
+		require.NotNil(t, pooled.buf)
+		bodies = append(bodies, pooled)
+
+		data, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.Equal(t, body, string(data))
+
+		var d Data
+		require.NoError(t, ParsedDataFromContext(req.Context(), &d))
+		require.Equal(t, "test", d.Name)
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	tests := []struct {
+		name       string
+		middleware func(r *Roamer) func(http.Handler) http.Handler
+	}{
+		{
+			name:       "Middleware",
+			middleware: Middleware[Data],
+		},
+		{
+			name: "ReleaseBody",
+			middleware: func(r *Roamer) func(http.Handler) http.Handler {
+				return func(next http.Handler) http.Handler {
+					return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+						var d Data
+						require.NoError(t, r.Parse(req, &d))
+						defer ReleaseBody(req)
+
+						next.ServeHTTP(w, req.WithContext(ContextWithParsedData(req.Context(), &d)))
+					})
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffers, bodies = nil, nil
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+			rec := httptest.NewRecorder()
+			tt.middleware(newRoamer())(http.HandlerFunc(handler)).ServeHTTP(rec, req)
+			require.Equal(t, http.StatusNoContent, rec.Code)
+
+			require.Len(t, bodies, 1)
+			require.Len(t, buffers, 1)
+			require.Nil(t, bodies[0].buf, "body is released after handler")
+			require.Zero(t, buffers[0].Len(), "buffer is reset")
+		})
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(body))
+	require.NoError(t, err)
+	require.NotPanics(t, func() { ReleaseBody(req) }, "other bodies are ignored")
+}
+
+func BenchmarkParse_PreserveBody(b *testing.B) {
+	type Data struct {
+		Name  string   `json:"name"`
+		Items []string `json:"items"`
+	}
+
+	body := []byte(`{"name":"test","items":["` + strings.Repeat("a", 4096) + `"]}`)
+
+	benchmarks := []struct {
+		name string
+		opt  OptionsFunc
+	}{
+		{
+			name: "buffer",
+			opt:  WithPreserveBody(),
+		},
+		{
+			name: "pool",
+			opt: WithPreserveBodyPool(&sync.Pool{
+				New: func() any {
+					return new(bytes.Buffer)
+				},
+			}),
+		},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			r := NewRoamer(WithDecoders(decoder.NewJSON()), bm.opt)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				req := http.Request{
+					Method:        http.MethodPost,
+					Header:        http.Header{"Content-Type": {decoder.ContentTypeJSON}},
+					Body:          io.NopCloser(bytes.NewReader(body)),
+					ContentLength: int64(len(body)),
+				}
+
+				var d Data
+				if err := r.Parse(&req, &d); err != nil {
+					b.Fatal(err)
+				}
+
+				_ = req.Body.Close()
+			}
+		})
+	}
+}
+
+func TestRoamer_Parse_PlainText(t *testing.T) {
+	type Webhook struct {
+		Event   string `header:"X-Event"`
+		Payload string `plain:"body"`
+	}
+
+	const body = "event: push\nref: refs/heads/main"
+
+	req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("X-Event", "push")
+
+	r := NewRoamer(
+		WithDecoders(decoder.NewJSON(), decoder.NewPlainText()),
+		WithParsers(parser.NewHeader()),
+		WithPreserveBody(),
+	)
+
+	var w Webhook
+	err = r.Parse(req, &w)
+	require.NoError(t, err)
+	require.Equal(t, Webhook{Event: "push", Payload: body}, w)
+
+	data, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, body, string(data))
+}
+
+func TestRoamer_Parse_MetaBodyHash(t *testing.T) {
+	type Data struct {
+		Name       string `json:"name"`
+		BodySHA256 string `meta:"body_sha256"`
+		BodySHA1   string `meta:"body_sha1"`
+	}
+
+	const body = `{"name":"test"}`
+
+	newRequest := func(t *testing.T) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+		return req
+	}
+
+	sha256Sum := sha256.Sum256([]byte(body))
+	sha1Sum := sha1.Sum([]byte(body)) //nolint:gosec // checksum
+
+	r := NewRoamer(
+		WithDecoders(decoder.NewJSON()),
+		WithParsers(parser.NewMeta()),
+		WithPreserveBody(),
+	)
+
+	var d Data
+	err := r.Parse(newRequest(t), &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{
+		Name:       "test",
+		BodySHA256: hex.EncodeToString(sha256Sum[:]),
+		BodySHA1:   hex.EncodeToString(sha1Sum[:]),
+	}, d)
+
+	d = Data{}
+	err = NewRoamer(WithDecoders(decoder.NewJSON()), WithParsers(parser.NewMeta())).Parse(newRequest(t), &d)
+	require.ErrorIs(t, err, rerr.BodyNotPreserved)
+
+	parseErr, ok := IsParseError(err)
+	require.True(t, ok)
+	require.Equal(t, "BodySHA256", parseErr.Field)
+}
+
+// cancelingReader cancels context after the first read.
+type cancelingReader struct {
+	data   []byte
+	cancel context.CancelFunc
+}
+
+func (c *cancelingReader) Read(p []byte) (int, error) {
+	if len(c.data) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p[:1], c.data)
+	c.data = c.data[n:]
+	c.cancel()
+
+	return n, nil
+}
+
+func TestRoamer_Parse_ContextCanceled(t *testing.T) {
+	type Data struct {
+		Name string `json:"name"`
+	}
+
+	for _, preserveBody := range []bool{false, true} {
+		t.Run(fmt.Sprintf("preserve body %t", preserveBody), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			body := &cancelingReader{data: []byte(`{"name":"test"}`), cancel: cancel}
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "test.com", io.NopCloser(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+			req.ContentLength = -1
+
+			opts := []OptionsFunc{WithDecoders(decoder.NewJSON())}
+			if preserveBody {
+				opts = append(opts, WithPreserveBody())
+			}
+
+			var d Data
+			err = NewRoamer(opts...).Parse(req, &d)
+			require.ErrorIs(t, err, context.Canceled)
+
+			_, ok := IsDecodeError(err)
+			require.True(t, ok)
+			require.Greater(t, len(body.data), 0, "body is not read after cancellation")
+		})
+	}
+}
+
+func TestRoamer_Parse_ContextCanceled_BlockedRead(t *testing.T) {
+	type Data struct {
+		Name string `json:"name"`
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	body, w := io.Pipe()
+	defer w.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "test.com", body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+	req.ContentLength = -1
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	var d Data
+	err = NewRoamer(WithDecoders(decoder.NewJSON())).Parse(req, &d)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, body, req.Body)
+}
+
+func TestRoamer_Parse_ContentDecoding(t *testing.T) {
+	type Data struct {
+		Name string `json:"name"`
+	}
+
+	const body = `{"name":"test"}`
+
+	compress := func(t *testing.T, encoding string) []byte {
+		t.Helper()
+
+		var buf bytes.Buffer
+
+		var w io.WriteCloser
+		switch encoding {
+		case EncodingGzip:
+			w = gzip.NewWriter(&buf)
+		case EncodingDeflate:
+			w = zlib.NewWriter(&buf)
+		}
+
+		_, err := w.Write([]byte(body))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name         string
+		encoding     string
+		body         []byte
+		opts         []OptionsFunc
+		preserveBody bool
+		want         Data
+		wantErr      error
+		decodeErr    bool
+	}{
+		{
+			name:     "gzip",
+			encoding: EncodingGzip,
+			body:     compress(t, EncodingGzip),
+			opts:     []OptionsFunc{WithContentDecoding()},
+			want:     Data{Name: "test"},
+		},
+		{
+			name:     "deflate",
+			encoding: EncodingDeflate,
+			body:     compress(t, EncodingDeflate),
+			opts:     []OptionsFunc{WithContentDecoding()},
+			want:     Data{Name: "test"},
+		},
+		{
+			name:         "gzip with preserved body",
+			encoding:     EncodingGzip,
+			body:         compress(t, EncodingGzip),
+			opts:         []OptionsFunc{WithContentDecoding(), WithPreserveBody()},
+			preserveBody: true,
+			want:         Data{Name: "test"},
+		},
+		{
+			name:     "size limit applies to decompressed body",
+			encoding: EncodingGzip,
+			body:     compress(t, EncodingGzip),
+			opts:     []OptionsFunc{WithContentDecoding(), WithRequestSizeLimit(int64(len(body)) - 1)},
+			wantErr:  rerr.RequestTooLarge,
+		},
+		{
+			name:      "malformed gzip",
+			encoding:  EncodingGzip,
+			body:      []byte(body),
+			opts:      []OptionsFunc{WithContentDecoding()},
+			decodeErr: true,
+		},
+		{
+			name:      "disabled",
+			encoding:  EncodingGzip,
+			body:      compress(t, EncodingGzip),
+			decodeErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "test.com", bytes.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+			req.Header.Set("Content-Encoding", tt.encoding)
+
+			var d Data
+			err = NewRoamer(append(tt.opts, WithDecoders(decoder.NewJSON()))...).Parse(req, &d)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			if tt.decodeErr {
+				_, ok := IsDecodeError(err)
+				require.True(t, ok)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+
+			if tt.preserveBody {
+				data, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				require.Equal(t, body, string(data))
+				require.Empty(t, req.Header.Get("Content-Encoding"))
+				require.Equal(t, int64(len(body)), req.ContentLength)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package roamer
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/slipros/roamer/value"
+)
+
+const (
+	// TagBool bool tag.
+	TagBool = "bool"
+	// BoolStrict value of bool tag restricting boolean literals to `true` and `false`, e.g. `bool:"strict"`.
+	BoolStrict = "strict"
+)
+
+// checkStrictBool checks that string value of bool field is exactly `true` or `false` case-insensitively,
+// by default all literals accepted by strconv.ParseBool are allowed.
+func checkStrictBool(tag reflect.StructTag, fieldValue reflect.Value, parsedValue any) error {
+	if tag.Get(TagBool) != BoolStrict {
+		return nil
+	}
+
+	t := fieldValue.Type()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Bool {
+		return nil
+	}
+
+	var str string
+	switch v := parsedValue.(type) {
+	case string:
+		str = v
+	case value.MultiValue:
+		str = v.String()
+	default:
+		return nil
+	}
+
+	if !strings.EqualFold(str, "true") && !strings.EqualFold(str, "false") {
+		return errors.Wrapf(rerr.NotAllowed, "boolean `%s`, only `true` or `false` are allowed", str)
+	}
+
+	return nil
+}
+
+// setAbsentFlag sets value of absent key into a field with flag option of parser tag,
+// e.g. `query:"no_cache,flag,invert"` is true without `no_cache` key. Pointer field is left nil.
+func (r *Roamer) setAbsentFlag(fieldType *reflect.StructField, fieldValue reflect.Value) error {
+	if fieldValue.Kind() == reflect.Pointer {
+		return nil
+	}
+
+	for _, tag := range r.parserTags {
+		tagValue, ok := fieldType.Tag.Lookup(tag)
+		if !ok {
+			continue
+		}
+
+		_, opts := parser.SplitTagValue(tagValue)
+		if !opts.Has(parser.TagOptionFlag) {
+			continue
+		}
+
+		flag, err := opts.FlagValue(nil, false)
+		if err != nil {
+			return errors.WithStack(rerr.ParseError{Field: fieldType.Name, Err: err})
+		}
+
+		if err := value.Set(fieldValue, strconv.FormatBool(flag)); err != nil {
+			return errors.Wrapf(err, "set absent flag value to field `%s`", fieldType.Name)
+		}
+
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package roamer
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoamer_Parse_StrictBool(t *testing.T) {
+	enabled := true
+
+	type Data struct {
+		Active  bool  `query:"active"`
+		Enabled *bool `query:"enabled" bool:"strict"`
+		Visible bool  `query:"visible" bool:"strict"`
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		want    Data
+		wantErr error
+	}{
+		{
+			name:  "strict literals",
+			query: "enabled=TRUE&visible=false",
+			want:  Data{Enabled: &enabled},
+		},
+		{
+			name:  "lenient literal by default",
+			query: "active=1",
+			want:  Data{Active: true},
+		},
+		{
+			name:    "lenient literal in strict field",
+			query:   "visible=1",
+			wantErr: rerr.NotAllowed,
+		},
+		{
+			name:    "lenient literal in strict pointer field",
+			query:   "enabled=t",
+			wantErr: rerr.NotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?"+tt.query, nil)
+			require.NoError(t, err)
+
+			var d Data
+			err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &d)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestRoamer_Parse_Flag(t *testing.T) {
+	type Data struct {
+		Cache   bool  `query:"no_cache,flag,invert"`
+		Verbose bool  `query:"verbose,flag"`
+		Debug   *bool `query:"debug,flag"`
+		Public  bool  `query:"private,invert"`
+	}
+
+	yes, no := true, false
+
+	tests := []struct {
+		name    string
+		query   string
+		want    Data
+		wantErr error
+	}{
+		{
+			name: "absent",
+			want: Data{Cache: true, Debug: nil},
+		},
+		{
+			name:  "present",
+			query: "no_cache&verbose&debug",
+			want:  Data{Cache: false, Verbose: true, Debug: &yes},
+		},
+		{
+			name:  "present with empty value",
+			query: "no_cache=&verbose=",
+			want:  Data{Cache: false, Verbose: true},
+		},
+		{
+			name:  "present with value",
+			query: "no_cache=false&verbose=true&debug=false&private=false",
+			want:  Data{Cache: true, Verbose: true, Debug: &no, Public: true},
+		},
+		{
+			name:    "invalid value",
+			query:   "no_cache=maybe",
+			wantErr: rerr.InvalidFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?"+tt.query, nil)
+			require.NoError(t, err)
+
+			var d Data
+			err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &d)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestCheckStrictBool(t *testing.T) {
+	var b bool
+	v := reflect.ValueOf(&b).Elem()
+
+	require.NoError(t, checkStrictBool(`bool:"strict"`, v, "True"))
+	require.NoError(t, checkStrictBool(``, v, "yes"))
+	require.NoError(t, checkStrictBool(`bool:"strict"`, reflect.ValueOf(new(int)).Elem(), "1"))
+	require.ErrorIs(t, checkStrictBool(`bool:"strict"`, v, "yes"), rerr.NotAllowed)
+	require.ErrorIs(t, checkStrictBool(`bool:"strict"`, v, parser.SplitValue{Raw: "0", Values: []string{"0"}}), rerr.NotAllowed)
+}
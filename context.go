@@ -37,6 +37,24 @@ func ParsedDataFromContext[T any](ctx context.Context, ptr *T) error {
 	return nil
 }
 
+// ParsedData returns pointer to parsed data from context.
+//
+// Unlike ParsedDataFromContext no copy is made: the returned pointer aliases the value
+// stored by the middleware, so it is shared by every holder of the context and
+// must not be mutated concurrently.
+func ParsedData[T any](ctx context.Context) (*T, bool) {
+	if _, ok := ctx.Value(ContextKeyParsingError).(error); ok {
+		return nil, false
+	}
+
+	v, ok := ctx.Value(ContextKeyParsedData).(*T)
+	if !ok || v == nil {
+		return nil, false
+	}
+
+	return v, true
+}
+
 // ContextWithParsedData returns a context with parsed data.
 func ContextWithParsedData(ctx context.Context, data any) context.Context {
 	return context.WithValue(ctx, ContextKeyParsedData, data)
@@ -3,6 +3,7 @@ package roamer
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -22,3 +23,65 @@ func TestContext(t *testing.T) {
 	require.NotEmpty(t, second, "not empty data")
 	require.NoError(t, err, "has error %v", err)
 }
+
+func TestParsedData(t *testing.T) {
+	ctxWithError := ContextWithParsingError(context.Background(), errBigBad)
+
+	data, ok := ParsedData[[]string](ctxWithError)
+	require.False(t, ok)
+	require.Nil(t, data)
+
+	data, ok = ParsedData[[]string](context.Background())
+	require.False(t, ok)
+	require.Nil(t, data)
+
+	stored := []string{"1", "2"}
+	ctxWithData := ContextWithParsedData(context.Background(), &stored)
+
+	data, ok = ParsedData[[]string](ctxWithData)
+	require.True(t, ok)
+	require.Same(t, &stored, data, "no copy is made")
+}
+
+type benchmarkContextData struct {
+	Strings []string
+	Int     int
+	Time    time.Time
+	Header  string
+	Query   string
+	Ints    [8]int64
+}
+
+var benchmarkContextSink *benchmarkContextData
+
+func BenchmarkParsedDataFromContext(b *testing.B) {
+	ctx := ContextWithParsedData(context.Background(), &benchmarkContextData{Int: 1})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var d benchmarkContextData
+		if err := ParsedDataFromContext(ctx, &d); err != nil {
+			b.Fatal(err)
+		}
+
+		benchmarkContextSink = &d
+	}
+}
+
+func BenchmarkParsedData(b *testing.B) {
+	ctx := ContextWithParsedData(context.Background(), &benchmarkContextData{Int: 1})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		d, ok := ParsedData[benchmarkContextData](ctx)
+		if !ok {
+			b.Fatal("no data")
+		}
+
+		benchmarkContextSink = d
+	}
+}
@@ -12,3 +12,9 @@ type Decoder interface {
 
 // Decoders is a map of decoders where keys are content types for given decoders.
 type Decoders map[string]Decoder
+
+// TaggedDecoder is a decoder reporting struct tags it uses, e.g. `json`, they are known in strict tags mode.
+type TaggedDecoder interface {
+	Decoder
+	Tags() []string
+}
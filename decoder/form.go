@@ -1,14 +1,18 @@
 package decoder
 
 import (
+	"encoding"
 	"net/http"
 	"net/url"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/slipros/exp"
 	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
 	"github.com/slipros/roamer/value"
 )
 
@@ -20,6 +24,8 @@ const (
 	tagValueFormURL = "form"
 )
 
+var typeTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
 // FormURLOptionsFunc function for setting options.
 type FormURLOptionsFunc func(*FormURL)
 
@@ -37,11 +43,36 @@ func WithSplitSymbol(splitSymbol string) FormURLOptionsFunc {
 	}
 }
 
+// WithBracketNotation enables bracket notation of form keys.
+//
+// Keys with `[]` suffix are the same as repeated keys, e.g. `items[]=a&items[]=b` fills `form:"items"` slice field.
+// Keys with `[key]` suffix fill fields of nested struct, e.g. `user[name]=x` fills `form:"name"` field of
+// `form:"user"` struct field, nesting is not limited: `user[address][city]=x`.
+// Keys with `[key]` suffix fill map field with string keys, e.g. `attrs[color]=red&attrs[color]=blue`
+// fills `form:"attrs"` map[string][]string field with both values, map[string]string receives the first value.
+// Keys with `[index][key]` suffix fill slice of structs, e.g. `contacts[0][name]=x` fills `form:"name"` field
+// of the first element of `form:"contacts"` slice field, elements keep order of indexes skipping gaps.
+func WithBracketNotation() FormURLOptionsFunc {
+	return func(f *FormURL) {
+		f.bracketNotation = true
+	}
+}
+
+// WithMergeQuery enables fallback to url query for keys missing in body,
+// values of keys present in both body and query are taken from body.
+func WithMergeQuery() FormURLOptionsFunc {
+	return func(f *FormURL) {
+		f.mergeQuery = true
+	}
+}
+
 // FormURL url form decoder.
 type FormURL struct {
 	contentType                 string
 	skipFilled                  bool
 	split                       bool
+	bracketNotation             bool
+	mergeQuery                  bool
 	splitSymbol                 string
 	experimentalFastStructField bool
 }
@@ -64,20 +95,32 @@ func NewFormURL(opts ...FormURLOptionsFunc) *FormURL {
 
 // Decode decodes url form value from http request into ptr.
 //
+// Tag value `*` captures all form values, the field receives a copy of them,
+// e.g. field of url.Values or map[string][]string type.
+//
 // ptr must have a type of either struct or map.
 func (f *FormURL) Decode(r *http.Request, ptr any) error {
 	if err := r.ParseForm(); err != nil {
 		return errors.WithMessage(err, "parse http form")
 	}
 
+	form := r.PostForm
+	if f.mergeQuery {
+		form = mergeQuery(form, r.URL.Query())
+	}
+
 	v := reflect.Indirect(reflect.ValueOf(ptr))
 	t := v.Type()
 
 	switch v.Kind() {
 	case reflect.Struct:
-		return f.parseStruct(&v, t, r.PostForm)
+		if f.bracketNotation {
+			form = subForm(form, "")
+		}
+
+		return f.parseStruct(&v, t, form)
 	case reflect.Map:
-		return f.parseMap(&v, t, r.PostForm)
+		return f.parseMap(&v, t, form)
 	default:
 		return errors.WithStack(rerr.NotSupported)
 	}
@@ -88,6 +131,11 @@ func (f *FormURL) EnableExperimentalFastStructFieldParser() {
 	f.experimentalFastStructField = true
 }
 
+// Tags returns struct tags used by decoder.
+func (f *FormURL) Tags() []string {
+	return []string{tagValueFormURL}
+}
+
 // ContentType returns content-type header value.
 func (f *FormURL) ContentType() string {
 	return f.contentType
@@ -103,22 +151,32 @@ func (f *FormURL) setSkipFilled(skip bool) {
 	f.skipFilled = skip
 }
 
-func (f *FormURL) parseFormValue(form url.Values, tag reflect.StructTag) (any, bool) {
+func (f *FormURL) parseFormValue(form url.Values, tag reflect.StructTag) (any, bool, error) {
 	tagValue, ok := tag.Lookup(tagValueFormURL)
 	if !ok {
-		return nil, false
+		return nil, false, nil
+	}
+
+	tagValue, opts := parser.SplitTagValue(tagValue)
+
+	if tagValue == parser.TagValueAll {
+		return form, len(form) > 0, nil
 	}
 
 	values, ok := form[tagValue]
-	if !ok {
-		return nil, false
+	if !ok || opts.SkipValue(values) || opts.IsNull(values) {
+		return nil, false, nil
+	}
+
+	if err := parser.CheckMaxItems(opts, len(values)); err != nil {
+		return nil, false, err
 	}
 
 	if len(values) == 1 {
-		return values[0], true
+		return values[0], true, nil
 	}
 
-	return values, true
+	return values, true, nil
 }
 
 func (f *FormURL) parseStruct(v *reflect.Value, t reflect.Type, form url.Values) (err error) {
@@ -140,8 +198,18 @@ func (f *FormURL) parseStruct(v *reflect.Value, t reflect.Type, form url.Values)
 			continue
 		}
 
-		formValue, ok := f.parseFormValue(form, fieldType.Tag)
+		formValue, ok, err := f.parseFormValue(form, fieldType.Tag)
+		if err != nil {
+			return errors.WithStack(rerr.ParseError{Field: fieldType.Name, Err: err})
+		}
+
 		if !ok {
+			if f.bracketNotation {
+				if err := f.parseNested(v.Field(i), fieldType.Tag, form); err != nil {
+					return errors.WithMessagef(err, "set nested value to field `%s`", fieldType.Name)
+				}
+			}
+
 			continue
 		}
 
@@ -158,6 +226,175 @@ func (f *FormURL) parseStruct(v *reflect.Value, t reflect.Type, form url.Values)
 	return nil
 }
 
+// parseNested fills struct field from form keys in bracket notation.
+func (f *FormURL) parseNested(fieldValue reflect.Value, tag reflect.StructTag, form url.Values) error {
+	tagValue, ok := tag.Lookup(tagValueFormURL)
+	if !ok {
+		return nil
+	}
+
+	tagValue, _ = parser.SplitTagValue(tagValue)
+	if isMapType(fieldValue.Type()) {
+		return f.parseNestedMap(fieldValue, subForm(form, tagValue))
+	}
+
+	if !isNestedType(fieldValue.Type()) {
+		return nil
+	}
+
+	nested := subForm(form, tagValue)
+	if len(nested) == 0 {
+		return nil
+	}
+
+	return f.setNested(fieldValue, nested)
+}
+
+// setNested sets nested form into a struct or slice of structs value.
+func (f *FormURL) setNested(fieldValue reflect.Value, form url.Values) error {
+	fieldType := fieldValue.Type()
+	switch fieldType.Kind() {
+	case reflect.Pointer:
+		if fieldValue.IsNil() {
+			fieldValue.Set(reflect.New(fieldType.Elem()))
+		}
+
+		return f.setNested(fieldValue.Elem(), form)
+	case reflect.Struct:
+		return f.parseStruct(&fieldValue, fieldType, form)
+	case reflect.Slice:
+		if f.skipFilled && !fieldValue.IsZero() {
+			return nil
+		}
+
+		return f.parseIndexed(fieldValue, form)
+	default:
+		return errors.WithStack(rerr.NotSupported)
+	}
+}
+
+// parseIndexed fills slice of structs from form keys with indexes, e.g. `0[name]`.
+//
+// Elements are placed in order of indexes, gaps between indexes are skipped.
+func (f *FormURL) parseIndexed(fieldValue reflect.Value, form url.Values) error {
+	indexes := make(map[int]string)
+	for k := range form {
+		index, _, found := strings.Cut(k, "[")
+		if !found {
+			continue
+		}
+
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 {
+			continue
+		}
+
+		indexes[i] = index
+	}
+
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	order := make([]int, 0, len(indexes))
+	for i := range indexes {
+		order = append(order, i)
+	}
+
+	slices.Sort(order)
+
+	s := reflect.MakeSlice(fieldValue.Type(), len(order), len(order))
+	for i, index := range order {
+		if err := f.setNested(s.Index(i), subForm(form, indexes[index])); err != nil {
+			return errors.WithStack(rerr.SliceIterationError{Err: err, Index: index})
+		}
+	}
+
+	fieldValue.Set(s)
+
+	return nil
+}
+
+// parseNestedMap fills map field from form keys with `[key]` suffix,
+// values of repeated keys are accumulated, e.g. `attrs[color]=red&attrs[color]=blue`.
+func (f *FormURL) parseNestedMap(fieldValue reflect.Value, form url.Values) error {
+	if len(form) == 0 || (f.skipFilled && !fieldValue.IsZero()) {
+		return nil
+	}
+
+	return value.SetMapSliceString(fieldValue, form)
+}
+
+// isMapType reports whether type is a map with string keys.
+func isMapType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+// isNestedType reports whether type can be filled from keys in bracket notation.
+func isNestedType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+	}
+
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(typeTextUnmarshaler)
+}
+
+// mergeQuery returns form with added query keys missing in form.
+func mergeQuery(form, query url.Values) url.Values {
+	if len(query) == 0 {
+		return form
+	}
+
+	merged := make(url.Values, len(form)+len(query))
+	for k, v := range query {
+		merged[k] = v
+	}
+
+	for k, v := range form {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// subForm returns form of keys nested into prefix key with stripped prefix, e.g. `user[name]` to `name`.
+//
+// Keys with `[]` suffix are merged into keys without it.
+func subForm(form url.Values, prefix string) url.Values {
+	sub := make(url.Values)
+	for k, values := range form {
+		if len(prefix) > 0 {
+			rest, ok := strings.CutPrefix(k, prefix+"[")
+			if !ok {
+				continue
+			}
+
+			end := strings.IndexByte(rest, ']')
+			if end <= 0 {
+				continue
+			}
+
+			k = rest[:end] + rest[end+1:]
+		}
+
+		k = strings.TrimSuffix(k, "[]")
+		sub[k] = append(sub[k], values...)
+	}
+
+	return sub
+}
+
 func (f *FormURL) parseMap(v *reflect.Value, t reflect.Type, form url.Values) error {
 	if t.Key().Kind() != reflect.String {
 		return errors.WithStack(rerr.NotSupported)
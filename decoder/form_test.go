@@ -1,6 +1,7 @@
 package decoder
 
 import (
+	stdjson "encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -16,6 +17,7 @@ func TestNewFormURL(t *testing.T) {
 	require.NotNil(t, f)
 	require.Equal(t, ContentTypeFormURL, f.ContentType())
 	require.Equal(t, f.splitSymbol, SplitSymbol)
+	require.Equal(t, []string{"form"}, f.Tags())
 
 	f = NewFormURL(WithDisabledSplit())
 	require.NotNil(t, f)
@@ -27,6 +29,10 @@ func TestNewFormURL(t *testing.T) {
 	require.Equal(t, ContentTypeFormURL, f.ContentType())
 	require.Equal(t, "=", f.splitSymbol)
 
+	f = NewFormURL(WithBracketNotation())
+	require.NotNil(t, f)
+	require.True(t, f.bracketNotation)
+
 	f = NewFormURL(WithContentType[*FormURL]("test"))
 	require.NotNil(t, f)
 	require.Equal(t, "test", f.ContentType())
@@ -646,6 +652,92 @@ func TestFormURL_Decode(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Fill slice at max items",
+			args: func() args {
+				type Data struct {
+					SliceString []string `form:"slice_string,maxitems=3"`
+				}
+
+				req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(form.Encode()))
+				require.NoError(t, err)
+
+				req.Header.Add("Content-Type", ContentTypeFormURL)
+
+				return args{
+					req:  req,
+					ptr:  &Data{},
+					want: &Data{SliceString: []string{str, str, str}},
+				}
+			},
+		},
+		{
+			name: "Skip empty value",
+			args: func() args {
+				type Data struct {
+					Name    *string `form:"name,skipempty"`
+					Surname *string `form:"surname"`
+				}
+
+				empty := ""
+
+				form := url.Values{"name": {""}, "surname": {""}}
+
+				req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(form.Encode()))
+				require.NoError(t, err)
+
+				req.Header.Add("Content-Type", ContentTypeFormURL)
+
+				return args{
+					req:  req,
+					ptr:  &Data{},
+					want: &Data{Surname: &empty},
+				}
+			},
+		},
+		{
+			name: "Null literal",
+			args: func() args {
+				type Data struct {
+					Name    *string `form:"name,null"`
+					Surname *string `form:"surname,null"`
+				}
+
+				surname := "test"
+
+				form := url.Values{"name": {"null"}, "surname": {surname}}
+
+				req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(form.Encode()))
+				require.NoError(t, err)
+
+				req.Header.Add("Content-Type", ContentTypeFormURL)
+
+				return args{
+					req:  req,
+					ptr:  &Data{},
+					want: &Data{Surname: &surname},
+				}
+			},
+		},
+		{
+			name:    "Error slice beyond max items",
+			wantErr: true,
+			args: func() args {
+				type Data struct {
+					SliceString []string `form:"slice_string,maxitems=2"`
+				}
+
+				req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(form.Encode()))
+				require.NoError(t, err)
+
+				req.Header.Add("Content-Type", ContentTypeFormURL)
+
+				return args{
+					req: req,
+					ptr: &Data{},
+				}
+			},
+		},
 		{
 			name:    "Unsupported ptr",
 			wantErr: true,
@@ -875,3 +967,287 @@ func BenchmarkFormURL_Decode_FastStructFieldParser(b *testing.B) {
 		}
 	}
 }
+
+func TestFormURL_Decode_BracketNotation(t *testing.T) {
+	type Address struct {
+		City string `form:"city"`
+	}
+
+	type User struct {
+		Name    string   `form:"name"`
+		Tags    []string `form:"tags"`
+		Address *Address `form:"address"`
+	}
+
+	type Data struct {
+		Items []string `form:"items"`
+		User  User     `form:"user"`
+		Owner *User    `form:"owner"`
+	}
+
+	tests := []struct {
+		name string
+		opts []FormURLOptionsFunc
+		body string
+		want Data
+	}{
+		{
+			name: "array style",
+			opts: []FormURLOptionsFunc{WithBracketNotation()},
+			body: "items[]=a&items[]=b",
+			want: Data{Items: []string{"a", "b"}},
+		},
+		{
+			name: "nested object style",
+			opts: []FormURLOptionsFunc{WithBracketNotation()},
+			body: "user[name]=x&user[tags][]=a&user[tags][]=b&user[address][city]=y",
+			want: Data{User: User{Name: "x", Tags: []string{"a", "b"}, Address: &Address{City: "y"}}},
+		},
+		{
+			name: "nested pointer is left nil without values",
+			opts: []FormURLOptionsFunc{WithBracketNotation()},
+			body: "user[name]=x",
+			want: Data{User: User{Name: "x"}},
+		},
+		{
+			name: "disabled",
+			body: "items[]=a&user[name]=x",
+			want: Data{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeFormURL)
+
+			var d Data
+			err = NewFormURL(tt.opts...).Decode(req, &d)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestFormURL_Decode_BracketNotation_Indexed(t *testing.T) {
+	type Contact struct {
+		Name  string `form:"name"`
+		Email string `form:"email"`
+	}
+
+	type Data struct {
+		Contacts []Contact  `form:"contacts"`
+		Owners   []*Contact `form:"owners"`
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want Data
+	}{
+		{
+			name: "two contacts",
+			body: "contacts[0][name]=A&contacts[0][email]=a@x&contacts[1][name]=B",
+			want: Data{Contacts: []Contact{{Name: "A", Email: "a@x"}, {Name: "B"}}},
+		},
+		{
+			name: "sparse indexes",
+			body: "contacts[7][name]=C&contacts[2][name]=A&contacts[5][email]=b@x",
+			want: Data{Contacts: []Contact{{Name: "A"}, {Email: "b@x"}, {Name: "C"}}},
+		},
+		{
+			name: "slice of pointers",
+			body: "owners[0][name]=A&owners[1][name]=B",
+			want: Data{Owners: []*Contact{{Name: "A"}, {Name: "B"}}},
+		},
+		{
+			name: "not indexed keys",
+			body: "contacts[name]=A",
+			want: Data{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeFormURL)
+
+			var d Data
+			err = NewFormURL(WithBracketNotation()).Decode(req, &d)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestFormURL_Decode_MergeQuery(t *testing.T) {
+	type Data struct {
+		Name  string   `form:"name"`
+		Page  int      `form:"page"`
+		Items []string `form:"items"`
+	}
+
+	tests := []struct {
+		name  string
+		opts  []FormURLOptionsFunc
+		query string
+		body  string
+		want  Data
+	}{
+		{
+			name:  "only in query",
+			opts:  []FormURLOptionsFunc{WithMergeQuery()},
+			query: "page=2&items=a&items=b",
+			body:  "name=x",
+			want:  Data{Name: "x", Page: 2, Items: []string{"a", "b"}},
+		},
+		{
+			name: "only in body",
+			opts: []FormURLOptionsFunc{WithMergeQuery()},
+			body: "name=x&page=3",
+			want: Data{Name: "x", Page: 3},
+		},
+		{
+			name:  "body wins",
+			opts:  []FormURLOptionsFunc{WithMergeQuery()},
+			query: "name=y&page=1&items=c",
+			body:  "name=x&page=3&items=a&items=b",
+			want:  Data{Name: "x", Page: 3, Items: []string{"a", "b"}},
+		},
+		{
+			name:  "disabled",
+			query: "page=2",
+			body:  "name=x",
+			want:  Data{Name: "x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "test.com?"+tt.query, strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeFormURL)
+
+			var d Data
+			err = NewFormURL(tt.opts...).Decode(req, &d)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "test.com?page=2&name=y", strings.NewReader("name=x"))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", ContentTypeFormURL)
+
+	var m map[string]string
+	err = NewFormURL(WithMergeQuery()).Decode(req, &m)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"name": "x", "page": "2"}, m)
+}
+
+func TestFormURL_Decode_RawMessage(t *testing.T) {
+	type Data struct {
+		Filter stdjson.RawMessage `form:"filter"`
+		Blob   []byte             `form:"blob"`
+	}
+
+	form := url.Values{}
+	form.Set("filter", `{"status":["new","done"]}`)
+	form.Set("blob", "raw,bytes")
+
+	req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", ContentTypeFormURL)
+
+	var d Data
+	err = NewFormURL().Decode(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, stdjson.RawMessage(`{"status":["new","done"]}`), d.Filter)
+	require.Equal(t, []byte("raw,bytes"), d.Blob)
+}
+
+func TestFormURL_Decode_BracketNotation_Map(t *testing.T) {
+	type Data struct {
+		Attrs  map[string][]string  `form:"attrs"`
+		Labels map[string]string    `form:"labels"`
+		Meta   *map[string][]string `form:"meta"`
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want Data
+	}{
+		{
+			name: "repeated values",
+			body: "attrs[color]=red&attrs[color]=blue&attrs[size]=L",
+			want: Data{Attrs: map[string][]string{"color": {"red", "blue"}, "size": {"L"}}},
+		},
+		{
+			name: "single values",
+			body: "attrs[color]=red&labels[env]=prod&labels[team]=core",
+			want: Data{
+				Attrs:  map[string][]string{"color": {"red"}},
+				Labels: map[string]string{"env": "prod", "team": "core"},
+			},
+		},
+		{
+			name: "array style values",
+			body: "meta[ids][]=1&meta[ids][]=2",
+			want: Data{Meta: &map[string][]string{"ids": {"1", "2"}}},
+		},
+		{
+			name: "no keys",
+			body: "other[color]=red",
+			want: Data{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypeFormURL)
+
+			var d Data
+			err = NewFormURL(WithBracketNotation()).Decode(req, &d)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestFormURL_Decode_All(t *testing.T) {
+	type Data struct {
+		Name string              `form:"name"`
+		Form url.Values          `form:"*"`
+		Raw  map[string][]string `form:"*"`
+	}
+
+	form := url.Values{}
+	form.Set("name", "x")
+	form.Add("items", "a")
+	form.Add("items", "b")
+
+	req, err := http.NewRequest(http.MethodPost, "test.com?page=2", strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", ContentTypeFormURL)
+
+	var d Data
+	err = NewFormURL().Decode(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, "x", d.Name)
+	require.Equal(t, form, d.Form)
+	require.Equal(t, map[string][]string(form), d.Raw)
+
+	d.Form["items"][0] = "changed"
+	d.Form.Set("name", "changed")
+	require.Equal(t, form, req.PostForm)
+
+	req, err = http.NewRequest(http.MethodPost, "test.com", strings.NewReader(""))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", ContentTypeFormURL)
+
+	var empty Data
+	err = NewFormURL().Decode(req, &empty)
+	require.NoError(t, err)
+	require.Nil(t, empty.Form)
+}
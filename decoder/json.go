@@ -3,6 +3,8 @@ package decoder
 import (
 	"io"
 	"net/http"
+	"reflect"
+	"strings"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
@@ -11,22 +13,52 @@ import (
 const (
 	// ContentTypeJSON content-type header for json decoder.
 	ContentTypeJSON = "application/json"
+	// ContentTypeJSONLD content-type header for json-ld decoder.
+	ContentTypeJSONLD = "application/ld+json"
+	tagJSON           = "json"
 )
 
-var json = jsoniter.ConfigCompatibleWithStandardLibrary
+var (
+	json = jsoniter.ConfigCompatibleWithStandardLibrary
+	// jsonAPI api of json decoder.
+	jsonAPI = newJSONAPI()
+)
 
 // JSONOptionsFunc function for setting json options.
 type JSONOptionsFunc = func(*JSON)
 
+// UnmarshalFunc function for unmarshalling data into ptr.
+type UnmarshalFunc = func(data []byte, ptr any) error
+
+// WithUnmarshaler sets unmarshal function used instead of default json decoding,
+// e.g. json.Unmarshal of goccy/go-json.
+func WithUnmarshaler(unmarshal UnmarshalFunc) JSONOptionsFunc {
+	return func(j *JSON) {
+		j.unmarshal = unmarshal
+	}
+}
+
+// WithMaxArrayLen sets max amount of elements of any json array in body,
+// body with longer array results in rerr.TooManyItems error before it is decoded.
+func WithMaxArrayLen(n int) JSONOptionsFunc {
+	return func(j *JSON) {
+		j.maxArrayLen = n
+	}
+}
+
 // JSON json decoder.
 type JSON struct {
 	contentType string
+	unmarshal   UnmarshalFunc
+	api         jsoniter.API
+	maxArrayLen int
 }
 
 // NewJSON returns new json decoder.
 func NewJSON(opts ...JSONOptionsFunc) *JSON {
 	j := JSON{
 		contentType: ContentTypeJSON,
+		api:         jsonAPI,
 	}
 
 	for _, opt := range opts {
@@ -36,9 +68,70 @@ func NewJSON(opts ...JSONOptionsFunc) *JSON {
 	return &j
 }
 
+// NewJSONLD returns new json decoder for json-ld content type.
+//
+// It can be registered alongside NewJSON to handle both media types.
+func NewJSONLD(opts ...JSONOptionsFunc) *JSON {
+	return NewJSON(append([]JSONOptionsFunc{WithContentType[*JSON](ContentTypeJSONLD)}, opts...)...)
+}
+
 // Decode decodes request body into ptr.
+//
+// Fields with jsonpath tag are filled from nested json paths after the body is decoded,
+// fields with missing path are left untouched.
+// Json string of field with `decode:"json"` tag is decoded again into the field, e.g. double-encoded object,
+// other json values of the field are decoded as usual. Custom unmarshal function doesn't support decode tag.
 func (j *JSON) Decode(r *http.Request, ptr any) error {
-	if err := json.NewDecoder(r.Body).Decode(ptr); err != nil {
+	var pathFields []jsonPathField
+	if t := reflect.TypeOf(ptr); t != nil && t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct {
+		pathFields = jsonPathFields(t.Elem())
+	}
+
+	if j.unmarshal != nil || len(pathFields) > 0 || j.maxArrayLen > 0 {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return errors.WithMessage(err, "read body")
+		}
+
+		if len(data) == 0 {
+			return nil
+		}
+
+		if j.maxArrayLen > 0 {
+			if err := checkArrayLen(data, j.maxArrayLen); err != nil {
+				return err
+			}
+		}
+
+		unmarshal := j.unmarshal
+		if unmarshal == nil {
+			unmarshal = j.api.Unmarshal
+		}
+
+		if err := unmarshal(data, ptr); err != nil {
+			return err
+		}
+
+		if len(pathFields) == 0 {
+			return nil
+		}
+
+		v := reflect.ValueOf(ptr).Elem()
+		for _, f := range pathFields {
+			raw, ok := lookupJSONPath(data, f.path)
+			if !ok {
+				continue
+			}
+
+			if err := unmarshal(raw, v.Field(f.index).Addr().Interface()); err != nil {
+				return errors.WithMessagef(err, "decode json path `%s`", strings.Join(f.path, jsonPathSeparator))
+			}
+		}
+
+		return nil
+	}
+
+	if err := j.api.NewDecoder(r.Body).Decode(ptr); err != nil {
 		if !errors.Is(err, io.EOF) {
 			return err
 		}
@@ -47,6 +140,11 @@ func (j *JSON) Decode(r *http.Request, ptr any) error {
 	return nil
 }
 
+// Tags returns struct tags used by decoder.
+func (j *JSON) Tags() []string {
+	return []string{tagJSON, TagJSONPath, TagDecode}
+}
+
 // ContentType returns content-type header value.
 func (j *JSON) ContentType() string {
 	return j.contentType
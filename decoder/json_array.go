@@ -0,0 +1,45 @@
+package decoder
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+// checkArrayLen checks that every array of json data has at most n elements counting tokens of data.
+//
+// Invalid json is left to be reported by decoding.
+func checkArrayLen(data []byte, n int) error {
+	dec := stdjson.NewDecoder(bytes.NewReader(data))
+
+	// amounts of elements of open arrays, -1 for open objects.
+	var counts []int
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			// end of data or invalid json.
+			return nil
+		}
+
+		if delim, ok := token.(stdjson.Delim); ok && (delim == ']' || delim == '}') {
+			counts = counts[:len(counts)-1]
+			continue
+		}
+
+		if last := len(counts) - 1; last >= 0 && counts[last] >= 0 {
+			counts[last]++
+			if counts[last] > n {
+				return errors.Wrapf(rerr.TooManyItems, "json array has more than %d elements", n)
+			}
+		}
+
+		switch token {
+		case stdjson.Delim('['):
+			counts = append(counts, 0)
+		case stdjson.Delim('{'):
+			counts = append(counts, -1)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package decoder
+
+import (
+	"reflect"
+	"strconv"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+)
+
+const (
+	jsonComplexReal = "real"
+	jsonComplexImag = "imag"
+)
+
+// WithComplexObjects enables decoding of complex64 and complex128 fields from json objects,
+// e.g. `{"real":1,"imag":2}`, strings, e.g. `"1+2i"`, and numbers as real part.
+//
+// It has no effect on a decoder with custom unmarshal function.
+func WithComplexObjects() JSONOptionsFunc {
+	return func(j *JSON) {
+		j.api = newJSONAPI(&complexExtension{})
+	}
+}
+
+// complexExtension jsoniter extension converting json values into complex numbers.
+type complexExtension struct {
+	jsoniter.DummyExtension
+}
+
+// CreateDecoder returns decoder for complex types.
+func (e *complexExtension) CreateDecoder(typ reflect2.Type) jsoniter.ValDecoder {
+	switch typ.Kind() {
+	case reflect.Complex64:
+		return &complexDecoder{bitSize: 64}
+	case reflect.Complex128:
+		return &complexDecoder{bitSize: 128}
+	default:
+		return nil
+	}
+}
+
+// complexDecoder decodes complex number of bit size.
+type complexDecoder struct {
+	bitSize int
+}
+
+// Decode decodes json value into complex number.
+func (d *complexDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	var c complex128
+	switch iter.WhatIsNext() {
+	case jsoniter.NilValue:
+		iter.ReadNil()
+		return
+	case jsoniter.NumberValue:
+		c = complex(iter.ReadFloat64(), 0)
+	case jsoniter.StringValue:
+		parsed, err := strconv.ParseComplex(iter.ReadString(), d.bitSize)
+		if err != nil {
+			iter.ReportError("decode complex", err.Error())
+			return
+		}
+
+		c = parsed
+	case jsoniter.ObjectValue:
+		var re, im float64
+		iter.ReadObjectCB(func(iter *jsoniter.Iterator, field string) bool {
+			switch field {
+			case jsonComplexReal:
+				re = iter.ReadFloat64()
+			case jsonComplexImag:
+				im = iter.ReadFloat64()
+			default:
+				iter.Skip()
+			}
+
+			return true
+		})
+
+		c = complex(re, im)
+	default:
+		iter.ReportError("decode complex", "expected object, string or number")
+		return
+	}
+
+	if iter.Error != nil {
+		return
+	}
+
+	if d.bitSize == 64 {
+		*(*complex64)(ptr) = complex64(c)
+		return
+	}
+
+	*(*complex128)(ptr) = c
+}
@@ -0,0 +1,78 @@
+package decoder
+
+import (
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	// TagDecode tag of field decoded again from json string, e.g. `json:"inner" decode:"json"`.
+	TagDecode = "decode"
+	// DecodeJSON value of decode tag for double-encoded json.
+	DecodeJSON = "json"
+)
+
+// newJSONAPI returns json api compatible with standard library supporting decode tag and extensions.
+func newJSONAPI(extensions ...jsoniter.Extension) jsoniter.API {
+	api := jsoniter.Config{
+		EscapeHTML:             true,
+		SortMapKeys:            true,
+		ValidateJsonRawMessage: true,
+	}.Froze()
+	api.RegisterExtension(&decodeExtension{})
+
+	for _, e := range extensions {
+		api.RegisterExtension(e)
+	}
+
+	return api
+}
+
+// decodeExtension jsoniter extension decoding fields with `decode:"json"` tag from json strings.
+type decodeExtension struct {
+	jsoniter.DummyExtension
+}
+
+// UpdateStructDescriptor wraps decoders of fields with decode tag.
+func (e *decodeExtension) UpdateStructDescriptor(sd *jsoniter.StructDescriptor) {
+	for _, binding := range sd.Fields {
+		if binding.Decoder == nil || binding.Field.Tag().Get(TagDecode) != DecodeJSON {
+			continue
+		}
+
+		binding.Decoder = &stringJSONDecoder{decoder: binding.Decoder}
+	}
+}
+
+// stringJSONDecoder decodes content of json string with decoder of field,
+// other json values are decoded as is.
+type stringJSONDecoder struct {
+	decoder jsoniter.ValDecoder
+}
+
+// Decode decodes json string content into field.
+func (d *stringJSONDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if iter.WhatIsNext() != jsoniter.StringValue {
+		d.decoder.Decode(ptr, iter)
+		return
+	}
+
+	str := iter.ReadString()
+	if iter.Error != nil || len(str) == 0 {
+		return
+	}
+
+	inner := iter.Pool().BorrowIterator([]byte(str))
+	defer iter.Pool().ReturnIterator(inner)
+
+	d.decoder.Decode(ptr, inner)
+	if inner.Error != nil {
+		iter.ReportError("decode json string", inner.Error.Error())
+		return
+	}
+
+	if inner.WhatIsNext() != 0 {
+		iter.ReportError("decode json string", "unexpected data after json value")
+	}
+}
@@ -0,0 +1,74 @@
+package decoder
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	// TagJSONPath tag of field filled from nested json path, e.g. `jsonpath:"user.address"`.
+	TagJSONPath = "jsonpath"
+	// jsonPathSeparator separator of json path keys.
+	jsonPathSeparator = "."
+)
+
+// jsonPathField field filled from nested json path.
+type jsonPathField struct {
+	index int
+	path  []string
+}
+
+// jsonPathFieldsCache cache of json path fields by struct type.
+var jsonPathFieldsCache sync.Map
+
+// jsonPathFields returns fields of struct which are filled from nested json paths.
+func jsonPathFields(t reflect.Type) []jsonPathField {
+	if cached, ok := jsonPathFieldsCache.Load(t); ok {
+		return cached.([]jsonPathField)
+	}
+
+	var fields []jsonPathField
+	for i := range t.NumField() {
+		fieldType := t.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		tagValue, ok := fieldType.Tag.Lookup(TagJSONPath)
+		if !ok || len(tagValue) == 0 {
+			continue
+		}
+
+		fields = append(fields, jsonPathField{
+			index: i,
+			path:  strings.Split(tagValue, jsonPathSeparator),
+		})
+	}
+
+	jsonPathFieldsCache.Store(t, fields)
+
+	return fields
+}
+
+// lookupJSONPath returns raw json value located by path.
+func lookupJSONPath(data []byte, path []string) ([]byte, bool) {
+	raw := data
+	for _, key := range path {
+		var object map[string]jsoniter.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return nil, false
+		}
+
+		v, ok := object[key]
+		if !ok {
+			return nil, false
+		}
+
+		raw = v
+	}
+
+	return raw, true
+}
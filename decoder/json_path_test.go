@@ -0,0 +1,74 @@
+package decoder
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON_Decode_JSONPath(t *testing.T) {
+	type Address struct {
+		City   string `json:"city"`
+		Street string `json:"street"`
+	}
+
+	type Data struct {
+		ID      int     `json:"id"`
+		Name    string  `jsonpath:"user.name"`
+		Address Address `jsonpath:"user.address"`
+		Country string  `jsonpath:"user.address.country"`
+		Phone   string  `jsonpath:"user.contacts.phone"`
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		want    Data
+		wantErr bool
+	}{
+		{
+			name: "Fill fields from nested paths",
+			body: `{"id":1,"user":{"name":"test","address":{"city":"Moscow","street":"Arbat","country":"RU"}}}`,
+			want: Data{
+				ID:      1,
+				Name:    "test",
+				Address: Address{City: "Moscow", Street: "Arbat"},
+				Country: "RU",
+			},
+		},
+		{
+			name: "Missing path leaves field zero",
+			body: `{"id":1,"user":{"name":"test"}}`,
+			want: Data{ID: 1, Name: "test"},
+		},
+		{
+			name: "Path through not an object leaves field zero",
+			body: `{"id":1,"user":"test"}`,
+			want: Data{ID: 1},
+		},
+		{
+			name:    "Error wrong type of nested value",
+			body:    `{"user":{"name":1}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			var d Data
+			err = NewJSON().Decode(req, &d)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
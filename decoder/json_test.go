@@ -1,10 +1,12 @@
 package decoder
 
 import (
+	stdjson "encoding/json"
 	"net/http"
 	"strings"
 	"testing"
 
+	rerr "github.com/slipros/roamer/err"
 	"github.com/stretchr/testify/require"
 )
 
@@ -12,12 +14,70 @@ func TestNewJSON(t *testing.T) {
 	j := NewJSON()
 	require.NotNil(t, j)
 	require.Equal(t, ContentTypeJSON, j.ContentType())
+	require.Equal(t, []string{"json", TagJSONPath, TagDecode}, j.Tags())
 
 	j = NewJSON(WithContentType[*JSON]("test"))
 	require.NotNil(t, j)
 	require.Equal(t, "test", j.ContentType())
 }
 
+func TestNewJSONLD(t *testing.T) {
+	j := NewJSONLD()
+	require.NotNil(t, j)
+	require.Equal(t, ContentTypeJSONLD, j.ContentType())
+
+	type Person struct {
+		Context string `json:"@context"`
+		Type    string `json:"@type"`
+		Name    string `json:"name"`
+	}
+
+	req, err := http.NewRequest(http.MethodPost, requestURL,
+		strings.NewReader(`{"@context":"https://schema.org","@type":"Person","name":"Jane Doe"}`))
+	require.NoError(t, err)
+
+	var p Person
+	err = j.Decode(req, &p)
+	require.NoError(t, err)
+	require.Equal(t, Person{Context: "https://schema.org", Type: "Person", Name: "Jane Doe"}, p)
+}
+
+func TestJSON_Decode_WithUnmarshaler(t *testing.T) {
+	type Data struct {
+		Field1 string `json:"field_1"`
+	}
+
+	var calls int
+	j := NewJSON(WithUnmarshaler(func(data []byte, ptr any) error {
+		calls++
+		return stdjson.Unmarshal(data, ptr)
+	}))
+	require.Equal(t, ContentTypeJSON, j.ContentType())
+
+	req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(`{"field_1":"value"}`))
+	require.NoError(t, err)
+
+	var d Data
+	err = j.Decode(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Field1: "value"}, d)
+	require.Equal(t, 1, calls)
+
+	req, err = http.NewRequest(http.MethodPost, requestURL, strings.NewReader(""))
+	require.NoError(t, err)
+
+	err = j.Decode(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, 1, calls, "empty body is not unmarshalled")
+
+	req, err = http.NewRequest(http.MethodPost, requestURL, strings.NewReader("{]"))
+	require.NoError(t, err)
+
+	err = j.Decode(req, &d)
+	require.Error(t, err)
+	require.Equal(t, 2, calls)
+}
+
 func TestJSON_Decode(t *testing.T) {
 	type args struct {
 		req  *http.Request
@@ -112,3 +172,247 @@ func TestJSON_Decode(t *testing.T) {
 		})
 	}
 }
+
+func TestJSON_Decode_WithComplexObjects(t *testing.T) {
+	type Data struct {
+		C64  complex64   `json:"c64"`
+		C128 complex128  `json:"c128"`
+		Ptr  *complex128 `json:"ptr"`
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		want    Data
+		wantErr bool
+	}{
+		{
+			name: "Object",
+			body: `{"c64":{"real":1,"imag":2},"c128":{"imag":-3.5,"real":0.5}}`,
+			want: Data{C64: complex(1, 2), C128: complex(0.5, -3.5)},
+		},
+		{
+			name: "String",
+			body: `{"c64":"1+2i","c128":"0.5-3.5i"}`,
+			want: Data{C64: complex(1, 2), C128: complex(0.5, -3.5)},
+		},
+		{
+			name: "Number and null",
+			body: `{"c128":4,"ptr":null}`,
+			want: Data{C128: complex(4, 0)},
+		},
+		{
+			name: "Pointer",
+			body: `{"ptr":{"real":1}}`,
+			want: Data{Ptr: func() *complex128 { c := complex(1, 0); return &c }()},
+		},
+		{
+			name:    "Invalid string",
+			body:    `{"c64":"not complex"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid type",
+			body:    `{"c64":true}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			var d Data
+			err = NewJSON(WithComplexObjects()).Decode(req, &d)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+
+	req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(`{"c64":{"real":1,"imag":2}}`))
+	require.NoError(t, err)
+
+	var d Data
+	err = NewJSON().Decode(req, &d)
+	require.Error(t, err, "objects are not decoded into complex without option")
+}
+
+func TestJSON_Decode_DoubleEncoded(t *testing.T) {
+	type Thing struct {
+		ID   int      `json:"id"`
+		Tags []string `json:"tags"`
+	}
+
+	type Data struct {
+		Name  string         `json:"name"`
+		Inner Thing          `json:"inner" decode:"json"`
+		Ptr   *Thing         `json:"ptr" decode:"json"`
+		Items []Thing        `json:"items" decode:"json"`
+		Attrs map[string]int `json:"attrs" decode:"json"`
+		Raw   string         `json:"raw"`
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		opts    []JSONOptionsFunc
+		want    Data
+		wantErr bool
+	}{
+		{
+			name: "Double-encoded object",
+			body: `{"name":"test","inner":"{\"id\":1,\"tags\":[\"a\",\"b\"]}","raw":"{\"id\":2}"}`,
+			want: Data{Name: "test", Inner: Thing{ID: 1, Tags: []string{"a", "b"}}, Raw: `{"id":2}`},
+		},
+		{
+			name: "Double-encoded pointer, array and map",
+			body: `{"ptr":"{\"id\":3}","items":"[{\"id\":4},{\"id\":5}]","attrs":"{\"a\":1}"}`,
+			want: Data{Ptr: &Thing{ID: 3}, Items: []Thing{{ID: 4}, {ID: 5}}, Attrs: map[string]int{"a": 1}},
+		},
+		{
+			name: "Plain json value",
+			body: `{"inner":{"id":6},"ptr":null}`,
+			want: Data{Inner: Thing{ID: 6}},
+		},
+		{
+			name: "Empty string",
+			body: `{"inner":""}`,
+			want: Data{},
+		},
+		{
+			name: "With complex objects",
+			body: `{"inner":"{\"id\":7}"}`,
+			opts: []JSONOptionsFunc{WithComplexObjects()},
+			want: Data{Inner: Thing{ID: 7}},
+		},
+		{
+			name:    "Invalid json string",
+			body:    `{"inner":"{\"id\":"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Data after json value",
+			body:    `{"inner":"{\"id\":1} {}"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			var d Data
+			err = NewJSON(tt.opts...).Decode(req, &d)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestJSON_Decode_WithMaxArrayLen(t *testing.T) {
+	type Item struct {
+		Tags []string `json:"tags"`
+	}
+
+	type Data struct {
+		IDs   []int  `json:"ids"`
+		Items []Item `json:"items"`
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		ptr     any
+		wantErr error
+	}{
+		{
+			name: "At limit",
+			body: `{"ids":[1,2,3],"items":[{"tags":["a","b","c"]}]}`,
+			ptr:  &Data{},
+		},
+		{
+			name:    "Beyond limit",
+			body:    `{"ids":[1,2,3,4]}`,
+			ptr:     &Data{},
+			wantErr: rerr.TooManyItems,
+		},
+		{
+			name:    "Nested beyond limit",
+			body:    `{"items":[{"tags":["a","b","c","d"]}]}`,
+			ptr:     &Data{},
+			wantErr: rerr.TooManyItems,
+		},
+		{
+			name: "Object keys are not counted",
+			body: `{"ids":[1],"items":[],"a":1,"b":2,"c":3,"d":4}`,
+			ptr:  &Data{},
+		},
+		{
+			name: "Top level array at limit",
+			body: `[[1,2,3],[4],[5]]`,
+			ptr:  &[][]int{},
+		},
+		{
+			name:    "Top level array beyond limit",
+			body:    `[1,2,3,4]`,
+			ptr:     &[]int{},
+			wantErr: rerr.TooManyItems,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			err = NewJSON(WithMaxArrayLen(3)).Decode(req, tt.ptr)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+
+	req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(`{"ids":[1,2,3],"items":[{"tags":["x"]}]}`))
+	require.NoError(t, err)
+
+	var d Data
+	err = NewJSON(WithMaxArrayLen(3)).Decode(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{IDs: []int{1, 2, 3}, Items: []Item{{Tags: []string{"x"}}}}, d)
+
+	req, err = http.NewRequest(http.MethodPost, requestURL, strings.NewReader(`{"ids":[1,`))
+	require.NoError(t, err)
+
+	err = NewJSON(WithMaxArrayLen(3)).Decode(req, &d)
+	require.Error(t, err, "invalid json is reported by decoding")
+}
+
+func TestJSON_Decode_RawMessage(t *testing.T) {
+	type Data struct {
+		Kind    string             `json:"kind"`
+		Payload stdjson.RawMessage `json:"payload"`
+	}
+
+	body := `{"kind":"event","payload":{"id": 1, "tags":["a","b"]}}`
+
+	req, err := http.NewRequest(http.MethodPost, requestURL, strings.NewReader(body))
+	require.NoError(t, err)
+
+	var d Data
+	err = NewJSON().Decode(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, "event", d.Kind)
+	require.Equal(t, stdjson.RawMessage(`{"id": 1, "tags":["a","b"]}`), d.Payload)
+}
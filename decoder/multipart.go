@@ -1,6 +1,9 @@
 package decoder
 
 import (
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -30,11 +33,36 @@ func WithMaxMemory(maxMemory int64) MultipartFormDataOptionsFunc {
 	}
 }
 
+// WithMaxFiles sets max amount of file parts in all fields, Decode returns rerr.TooManyItems error
+// if there are more files, zero means no limit.
+//
+// Files are counted while parts are read, so parts after the exceeding file are not read at all.
+func WithMaxFiles(n int) MultipartFormDataOptionsFunc {
+	return func(m *MultipartFormData) {
+		m.maxFiles = n
+	}
+}
+
+// WithPartUnmarshaler sets unmarshal function of file parts with content type,
+// e.g. `application/yaml` part is unmarshalled into a struct field instead of binding it as a file.
+func WithPartUnmarshaler(contentType string, unmarshal UnmarshalFunc) MultipartFormDataOptionsFunc {
+	return func(m *MultipartFormData) {
+		m.partUnmarshalers[contentType] = unmarshal
+	}
+}
+
+var (
+	typeMultipartFile  = reflect.TypeOf(MultipartFile{})
+	typeMultipartFiles = reflect.TypeOf(MultipartFiles{})
+)
+
 // MultipartFormData multipart form-data decoder.
 type MultipartFormData struct {
 	contentType                 string
 	skipFilled                  bool
 	maxMemory                   int64
+	maxFiles                    int
+	partUnmarshalers            map[string]UnmarshalFunc
 	experimentalFastStructField bool
 }
 
@@ -44,6 +72,9 @@ func NewMultipartFormData(opts ...MultipartFormDataOptionsFunc) *MultipartFormDa
 		contentType: ContentTypeMultipartFormData,
 		skipFilled:  true,
 		maxMemory:   defaultMultipartFormDataMaxMemory,
+		partUnmarshalers: map[string]UnmarshalFunc{
+			ContentTypeJSON: json.Unmarshal,
+		},
 	}
 
 	for _, opt := range opts {
@@ -55,10 +86,14 @@ func NewMultipartFormData(opts ...MultipartFormDataOptionsFunc) *MultipartFormDa
 
 // Decode decodes url form value from http request into ptr.
 //
+// File part with content type of part unmarshaler, e.g. `application/json`, is unmarshalled into a field
+// which is not a file, e.g. nested struct, other file parts are bound as files.
+// Parts without filename are form values, their content type is not known.
+//
 // ptr must be pointer to a struct.
 func (m *MultipartFormData) Decode(r *http.Request, ptr any) error {
-	if err := r.ParseMultipartForm(m.maxMemory); err != nil {
-		return errors.WithMessage(err, "parse multipart form")
+	if err := m.parseMultipartForm(r); err != nil {
+		return err
 	}
 
 	v := reflect.Indirect(reflect.ValueOf(ptr))
@@ -76,6 +111,11 @@ func (m *MultipartFormData) EnableExperimentalFastStructFieldParser() {
 	m.experimentalFastStructField = true
 }
 
+// Tags returns struct tags used by decoder.
+func (m *MultipartFormData) Tags() []string {
+	return []string{tagValueMultipartFormData}
+}
+
 // ContentType returns content type of url form decoder.
 func (m *MultipartFormData) ContentType() string {
 	return m.contentType
@@ -91,6 +131,125 @@ func (m *MultipartFormData) setSkipFilled(skip bool) {
 	m.skipFilled = skip
 }
 
+// parseMultipartForm parses multipart form of request like http.Request.ParseMultipartForm
+// counting files while parts are read if max files is set.
+func (m *MultipartFormData) parseMultipartForm(r *http.Request) error {
+	if m.maxFiles <= 0 || r.MultipartForm != nil {
+		if err := r.ParseMultipartForm(m.maxMemory); err != nil {
+			return errors.WithMessage(err, "parse multipart form")
+		}
+
+		return m.checkFiles(r.MultipartForm)
+	}
+
+	var parseFormErr error
+	if r.Form == nil {
+		parseFormErr = r.ParseForm()
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return errors.WithMessage(err, "parse multipart form")
+	}
+
+	form, err := m.readForm(mr)
+	if err != nil {
+		return errors.WithMessage(err, "parse multipart form")
+	}
+
+	if r.PostForm == nil {
+		r.PostForm = make(url.Values)
+	}
+
+	for k, v := range form.Value {
+		r.Form[k] = append(r.Form[k], v...)
+		r.PostForm[k] = append(r.PostForm[k], v...)
+	}
+
+	r.MultipartForm = form
+
+	if parseFormErr != nil {
+		return errors.WithMessage(parseFormErr, "parse multipart form")
+	}
+
+	return nil
+}
+
+// readForm reads form from parts of reader which are passed to multipart.Reader.ReadForm through a pipe,
+// reading stops before content of the file part exceeding max files.
+func (m *MultipartFormData) readForm(mr *multipart.Reader) (*multipart.Form, error) {
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(m.copyParts(w, mr))
+	}()
+
+	form, err := multipart.NewReader(pr, w.Boundary()).ReadForm(m.maxMemory)
+
+	// unblock and wait for copying, so body of request is not read after return.
+	_ = pr.Close()
+	<-done
+
+	return form, err
+}
+
+// copyParts copies raw parts of reader into writer counting file parts.
+func (m *MultipartFormData) copyParts(w *multipart.Writer, mr *multipart.Reader) error {
+	var files int
+	for {
+		part, err := mr.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			return w.Close()
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if len(part.FileName()) > 0 {
+			files++
+			if files > m.maxFiles {
+				return errors.Wrapf(rerr.TooManyItems, "multipart files: more than %d", m.maxFiles)
+			}
+		}
+
+		dst, err := w.CreatePart(part.Header)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(dst, part); err != nil {
+			return err
+		}
+	}
+}
+
+// checkFiles checks amount of files in already parsed form against max files,
+// temporary files of form are removed on error.
+func (m *MultipartFormData) checkFiles(form *multipart.Form) error {
+	if m.maxFiles <= 0 || form == nil {
+		return nil
+	}
+
+	var n int
+	for _, headers := range form.File {
+		n += len(headers)
+	}
+
+	if n <= m.maxFiles {
+		return nil
+	}
+
+	if err := form.RemoveAll(); err != nil {
+		return errors.WithMessage(err, "remove multipart files")
+	}
+
+	return errors.Wrapf(rerr.TooManyItems, "multipart files: %d, max: %d", n, m.maxFiles)
+}
+
 // parseStruct parses structure from http request into a ptr.
 func (m *MultipartFormData) parseStruct(r *http.Request, v *reflect.Value) (err error) {
 	t := v.Type()
@@ -119,7 +278,7 @@ func (m *MultipartFormData) parseStruct(r *http.Request, v *reflect.Value) (err
 		}
 
 		if len(r.Form) > 0 {
-			if formValue, ok := m.parseFormValue(r.Form, tagValue); ok {
+			if formValue, ok := m.parseFormValue(r.Form, tagValue, fieldType.Type); ok {
 				fieldValue := v.Field(i)
 				if m.skipFilled && !fieldValue.IsZero() {
 					continue
@@ -158,7 +317,22 @@ func (m *MultipartFormData) parseStruct(r *http.Request, v *reflect.Value) (err
 					tagValue, fieldType.Name)
 			}
 		default:
-			if files := r.MultipartForm.File[tagValue]; len(files) == 0 {
+			files := r.MultipartForm.File[tagValue]
+			if len(files) == 0 {
+				continue
+			}
+
+			if unmarshal, ok := m.partUnmarshaler(v.Field(i), files[0]); ok {
+				fieldValue := v.Field(i)
+				if m.skipFilled && !fieldValue.IsZero() {
+					continue
+				}
+
+				if err := unmarshalPart(fieldValue, files[0], unmarshal); err != nil {
+					return errors.WithMessagef(err, "unmarshal `%s` multipart part to field `%s`",
+						tagValue, fieldType.Name)
+				}
+
 				continue
 			}
 
@@ -188,19 +362,69 @@ func (m *MultipartFormData) parseStruct(r *http.Request, v *reflect.Value) (err
 	return nil
 }
 
-func (m *MultipartFormData) parseFormValue(form url.Values, tagValue string) (any, bool) {
+// partUnmarshaler returns unmarshal function of part content type if field is not a file.
+func (m *MultipartFormData) partUnmarshaler(field reflect.Value, header *multipart.FileHeader) (UnmarshalFunc, bool) {
+	if len(m.partUnmarshalers) == 0 {
+		return nil, false
+	}
+
+	t := field.Type()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == typeMultipartFile || t == typeMultipartFiles {
+		return nil, false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, false
+	}
+
+	unmarshal, ok := m.partUnmarshalers[mediaType]
+	return unmarshal, ok
+}
+
+// unmarshalPart unmarshals content of part into a field.
+func unmarshalPart(field reflect.Value, header *multipart.FileHeader, unmarshal UnmarshalFunc) error {
+	file, err := header.Open()
+	if err != nil {
+		return errors.WithMessage(err, "open part")
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return errors.WithMessage(err, "read part")
+	}
+
+	return unmarshal(data, field.Addr().Interface())
+}
+
+// parseFormValue returns values of repeated form fields for slice field, otherwise the first value.
+func (m *MultipartFormData) parseFormValue(form url.Values, tagValue string, t reflect.Type) (any, bool) {
 	values, ok := form[tagValue]
 	if !ok {
 		return nil, false
 	}
 
-	if len(values) == 1 {
+	if len(values) == 1 || !isSliceType(t) {
 		return values[0], true
 	}
 
 	return values, true
 }
 
+// isSliceType reports whether type is a slice except slice of bytes.
+func isSliceType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
 func (m *MultipartFormData) setFileValue(field reflect.Value, value any) error {
 	if field.Kind() == reflect.Pointer && field.IsNil() {
 		// init ptr
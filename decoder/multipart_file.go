@@ -1,8 +1,10 @@
 package decoder
 
 import (
+	"io"
 	"mime/multipart"
 
+	"github.com/pkg/errors"
 	rerr "github.com/slipros/roamer/err"
 )
 
@@ -36,6 +38,18 @@ func (f *MultipartFile) Copy() (MultipartFile, error) {
 	return cp, nil
 }
 
+// Reader returns new reader of file content from its beginning, e.g. to stream the file to storage.
+//
+// Content is kept in memory or in temporary file which is removed by http server after the handler returns,
+// so the reader must be read and closed while the request is being handled.
+func (f *MultipartFile) Reader() (io.ReadCloser, error) {
+	if f.Header == nil {
+		return nil, errors.Wrap(rerr.NilValue, "file header")
+	}
+
+	return f.Header.Open()
+}
+
 // MultipartFiles parsed multipart form-data files.
 type MultipartFiles []MultipartFile
 
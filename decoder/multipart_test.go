@@ -2,11 +2,14 @@ package decoder
 
 import (
 	"bytes"
+	"io"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
 	"testing"
 
+	rerr "github.com/slipros/roamer/err"
 	"github.com/stretchr/testify/require"
 )
 
@@ -28,12 +31,17 @@ func TestNewMultipartFormData(t *testing.T) {
 	m := NewMultipartFormData()
 	require.NotNil(t, m)
 	require.Equal(t, ContentTypeMultipartFormData, m.ContentType())
+	require.Equal(t, []string{"multipart"}, m.Tags())
 
 	m = NewMultipartFormData(WithMaxMemory(1000))
 	require.NotNil(t, m)
 	require.Equal(t, ContentTypeMultipartFormData, m.ContentType())
 	require.Equal(t, int64(1000), m.maxMemory)
 
+	m = NewMultipartFormData(WithMaxFiles(3))
+	require.NotNil(t, m)
+	require.Equal(t, 3, m.maxFiles)
+
 	m = NewMultipartFormData(WithContentType[*MultipartFormData]("test"))
 	require.NotNil(t, m)
 	require.Equal(t, "test", m.ContentType())
@@ -272,3 +280,321 @@ func prepareMultipartFormDataArgs() (r *http.Request, ptr *multipartFormDataTest
 
 	return r, &multipartFormDataTestData{}, want
 }
+
+func TestMultipartFormData_Decode_JSONPart(t *testing.T) {
+	type Meta struct {
+		Title string   `json:"title"`
+		Tags  []string `json:"tags"`
+	}
+
+	type Data struct {
+		Meta     Meta           `multipart:"meta"`
+		MetaPtr  *Meta          `multipart:"meta"`
+		MetaFile *MultipartFile `multipart:"meta"`
+		Image    MultipartFile  `multipart:"image"`
+		Name     string         `multipart:"name"`
+	}
+
+	newRequest := func(t *testing.T, meta string) *http.Request {
+		t.Helper()
+
+		var b bytes.Buffer
+		w := multipart.NewWriter(&b)
+
+		require.NoError(t, w.WriteField("name", "photo"))
+
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", `form-data; name="meta"; filename="blob"`)
+		h.Set("Content-Type", "application/json; charset=utf-8")
+		part, err := w.CreatePart(h)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(meta))
+		require.NoError(t, err)
+
+		image, err := w.CreateFormFile("image", "image.png")
+		require.NoError(t, err)
+		_, err = image.Write([]byte("png"))
+		require.NoError(t, err)
+
+		require.NoError(t, w.Close())
+
+		r, err := http.NewRequest(http.MethodPost, requestURL, &b)
+		require.NoError(t, err)
+		r.Header.Set("Content-Type", w.FormDataContentType())
+
+		return r
+	}
+
+	var d Data
+	err := NewMultipartFormData().Decode(newRequest(t, `{"title":"sunset","tags":["sea","sky"]}`), &d)
+	require.NoError(t, err)
+
+	want := Meta{Title: "sunset", Tags: []string{"sea", "sky"}}
+	require.Equal(t, want, d.Meta)
+	require.NotNil(t, d.MetaPtr)
+	require.Equal(t, want, *d.MetaPtr)
+	require.NotNil(t, d.MetaFile, "file field still receives the part")
+	require.Equal(t, "application/json; charset=utf-8", d.MetaFile.ContentType())
+	require.Equal(t, "image", d.Image.Key)
+	require.NotNil(t, d.Image.File)
+	require.Equal(t, "photo", d.Name)
+
+	d = Data{}
+	err = NewMultipartFormData().Decode(newRequest(t, `{"title":`), &d)
+	require.Error(t, err)
+
+	type RawData struct {
+		Meta string `multipart:"meta"`
+	}
+
+	var rd RawData
+	err = NewMultipartFormData(WithPartUnmarshaler(ContentTypeJSON, func(data []byte, ptr any) error {
+		*ptr.(*string) = string(data)
+		return nil
+	})).Decode(newRequest(t, `raw`), &rd)
+	require.NoError(t, err)
+	require.Equal(t, "raw", rd.Meta)
+}
+
+func TestMultipartFormData_Decode_MaxFiles(t *testing.T) {
+	type Data struct {
+		Avatar    MultipartFile  `multipart:"avatar"`
+		Documents MultipartFiles `multipart:",allfiles"`
+		Name      string         `multipart:"name"`
+	}
+
+	const maxFiles = 3
+
+	newRequest := func(t *testing.T, avatars, documents int) *http.Request {
+		t.Helper()
+
+		var b bytes.Buffer
+		w := multipart.NewWriter(&b)
+
+		require.NoError(t, w.WriteField("name", "test"))
+
+		write := func(field string, n int) {
+			for range n {
+				part, err := w.CreateFormFile(field, field+".txt")
+				require.NoError(t, err)
+				_, err = part.Write([]byte(field))
+				require.NoError(t, err)
+			}
+		}
+
+		write("avatar", avatars)
+		write("document", documents)
+
+		require.NoError(t, w.Close())
+
+		r, err := http.NewRequest(http.MethodPost, requestURL, &b)
+		require.NoError(t, err)
+		r.Header.Set("Content-Type", w.FormDataContentType())
+
+		return r
+	}
+
+	tests := []struct {
+		name      string
+		avatars   int
+		documents int
+		opts      []MultipartFormDataOptionsFunc
+		wantErr   bool
+	}{
+		{
+			name:      "Max files",
+			avatars:   1,
+			documents: maxFiles - 1,
+			opts:      []MultipartFormDataOptionsFunc{WithMaxFiles(maxFiles)},
+		},
+		{
+			name:      "Too many files across fields",
+			avatars:   1,
+			documents: maxFiles,
+			opts:      []MultipartFormDataOptionsFunc{WithMaxFiles(maxFiles)},
+			wantErr:   true,
+		},
+		{
+			name:      "Too many files in a field",
+			documents: maxFiles + 1,
+			opts:      []MultipartFormDataOptionsFunc{WithMaxFiles(maxFiles)},
+			wantErr:   true,
+		},
+		{
+			name:      "Without limit",
+			avatars:   1,
+			documents: maxFiles * 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newRequest(t, tt.avatars, tt.documents)
+
+			var d Data
+			err := NewMultipartFormData(tt.opts...).Decode(req, &d)
+			if tt.wantErr {
+				require.ErrorIs(t, err, rerr.TooManyItems)
+				require.Empty(t, d.Name, "struct is not filled")
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "test", d.Name)
+			require.Len(t, req.MultipartForm.File["document"], tt.documents)
+		})
+	}
+}
+
+// trackingReader reports whether it was read.
+type trackingReader struct {
+	io.Reader
+	read bool
+}
+
+func (t *trackingReader) Read(p []byte) (int, error) {
+	t.read = true
+	return t.Reader.Read(p)
+}
+
+func TestMultipartFormData_Decode_MaxFiles_StopsReading(t *testing.T) {
+	type Data struct {
+		Documents MultipartFiles `multipart:",allfiles"`
+	}
+
+	const maxFiles = 2
+
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+
+	// content of the exceeding file is larger than buffer of multipart reader,
+	// so parts after it can be read only if the file is read.
+	for i := range maxFiles + 1 {
+		part, err := w.CreateFormFile("document", "document.txt")
+		require.NoError(t, err)
+
+		content := []byte("document")
+		if i == maxFiles {
+			content = bytes.Repeat(content, 8<<10)
+		}
+
+		_, err = part.Write(content)
+		require.NoError(t, err)
+	}
+
+	head := b.Len()
+
+	for range 10 {
+		part, err := w.CreateFormFile("document", "document.txt")
+		require.NoError(t, err)
+		_, err = part.Write([]byte("document"))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, w.Close())
+
+	tail := &trackingReader{Reader: bytes.NewReader(b.Bytes()[head:])}
+
+	req, err := http.NewRequest(http.MethodPost, requestURL, io.MultiReader(bytes.NewReader(b.Bytes()[:head]), tail))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	var d Data
+	err = NewMultipartFormData(WithMaxFiles(maxFiles)).Decode(req, &d)
+	require.ErrorIs(t, err, rerr.TooManyItems)
+	require.False(t, tail.read, "parts after the exceeding file are not read")
+	require.Empty(t, d.Documents)
+}
+
+func TestMultipartFile_Reader(t *testing.T) {
+	type Data struct {
+		File MultipartFile `multipart:"file"`
+	}
+
+	content := bytes.Repeat([]byte("roamer"), 1024)
+
+	tests := []struct {
+		name      string
+		maxMemory int64
+	}{
+		{
+			name:      "in memory",
+			maxMemory: defaultMultipartFormDataMaxMemory,
+		},
+		{
+			name:      "in temporary file",
+			maxMemory: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			w := multipart.NewWriter(&b)
+
+			part, err := w.CreateFormFile("file", "file.txt")
+			require.NoError(t, err)
+			_, err = part.Write(content)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			req, err := http.NewRequest(http.MethodPost, requestURL, &b)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", w.FormDataContentType())
+
+			var d Data
+			err = NewMultipartFormData(WithMaxMemory(tt.maxMemory)).Decode(req, &d)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				require.NoError(t, req.MultipartForm.RemoveAll())
+			})
+
+			_, err = d.File.File.Read(make([]byte, 10))
+			require.NoError(t, err)
+
+			reader, err := d.File.Reader()
+			require.NoError(t, err)
+
+			read, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			require.NoError(t, reader.Close())
+			require.Equal(t, content, read, "reader starts from beginning regardless of File offset")
+		})
+	}
+
+	var f MultipartFile
+	_, err := f.Reader()
+	require.ErrorIs(t, err, rerr.NilValue)
+}
+
+func TestMultipartFormData_Decode_RepeatedFields(t *testing.T) {
+	type Data struct {
+		Tags    []string  `multipart:"tag"`
+		TagsPtr *[]string `multipart:"tag"`
+		Tag     string    `multipart:"tag"`
+		IDs     []int     `multipart:"id"`
+		Name    []string  `multipart:"name"`
+	}
+
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+
+	for _, field := range [][2]string{{"tag", "a"}, {"tag", "b"}, {"id", "1"}, {"id", "2"}, {"name", "single"}} {
+		require.NoError(t, w.WriteField(field[0], field[1]))
+	}
+
+	require.NoError(t, w.Close())
+
+	req, err := http.NewRequest(http.MethodPost, requestURL, &b)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	var d Data
+	err = NewMultipartFormData().Decode(req, &d)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"a", "b"}, d.Tags)
+	require.NotNil(t, d.TagsPtr)
+	require.Equal(t, []string{"a", "b"}, *d.TagsPtr)
+	require.Equal(t, "a", d.Tag, "scalar field keeps the first value")
+	require.Equal(t, []int{1, 2}, d.IDs)
+	require.Equal(t, []string{"single"}, d.Name)
+}
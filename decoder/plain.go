@@ -0,0 +1,103 @@
+package decoder
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/value"
+)
+
+const (
+	// ContentTypePlainText content-type header for plain text decoder.
+	ContentTypePlainText = "text/plain"
+	// TagPlainText tag of field receiving plain text body, e.g. `plain:"body"`.
+	TagPlainText = "plain"
+	// PlainTextBody value of plain text tag.
+	PlainTextBody = "body"
+)
+
+// PlainTextOptionsFunc function for setting plain text options.
+type PlainTextOptionsFunc = func(*PlainText)
+
+// PlainText plain text decoder, whole body is read into a field with `plain:"body"` tag.
+type PlainText struct {
+	contentType string
+}
+
+// NewPlainText returns new plain text decoder.
+func NewPlainText(opts ...PlainTextOptionsFunc) *PlainText {
+	p := PlainText{
+		contentType: ContentTypePlainText,
+	}
+
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	return &p
+}
+
+// Decode reads request body into field of ptr with `plain:"body"` tag,
+// the field can have string or []byte type, body is set as is without charset conversion.
+//
+// ptr can be a pointer to a struct, string or []byte.
+func (p *PlainText) Decode(r *http.Request, ptr any) error {
+	v := reflect.Indirect(reflect.ValueOf(ptr))
+
+	var field reflect.Value
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			fieldType := t.Field(i)
+			if fieldType.IsExported() && fieldType.Tag.Get(TagPlainText) == PlainTextBody {
+				field = v.Field(i)
+				break
+			}
+		}
+
+		if !field.IsValid() {
+			return nil
+		}
+	default:
+		field = v
+	}
+
+	if !isPlainTextType(field.Type()) {
+		return errors.Wrapf(rerr.NotSupported, "%s", field.Type())
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.WithMessage(err, "read plain text body")
+	}
+
+	return value.Set(field, string(data))
+}
+
+// isPlainTextType reports whether type is a string or slice of bytes, including pointers to them.
+func isPlainTextType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.String || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+}
+
+// Tags returns struct tags used by decoder.
+func (p *PlainText) Tags() []string {
+	return []string{TagPlainText}
+}
+
+// ContentType returns content-type header value.
+func (p *PlainText) ContentType() string {
+	return p.contentType
+}
+
+// setContentType set content-type value.
+func (p *PlainText) setContentType(contentType string) {
+	p.contentType = contentType
+}
@@ -0,0 +1,121 @@
+package decoder
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPlainText(t *testing.T) {
+	p := NewPlainText()
+	require.NotNil(t, p)
+	require.Equal(t, ContentTypePlainText, p.ContentType())
+	require.Equal(t, []string{TagPlainText}, p.Tags())
+
+	p = NewPlainText(WithContentType[*PlainText]("test"))
+	require.NotNil(t, p)
+	require.Equal(t, "test", p.ContentType())
+}
+
+func TestPlainText_Decode(t *testing.T) {
+	type Text struct {
+		ID   int    `json:"id"`
+		Body string `plain:"body"`
+	}
+
+	type Binary struct {
+		Body []byte `plain:"body"`
+	}
+
+	type Pointer struct {
+		Body *string `plain:"body"`
+	}
+
+	type Untagged struct {
+		Body string
+	}
+
+	type Invalid struct {
+		Body int `plain:"body"`
+	}
+
+	binary := []byte{0x00, 0xff, 0xfe, 0x80, '\n', 0x01}
+	text := "Привет, мир! 👋\nsecond line"
+	short := "text"
+
+	tests := []struct {
+		name    string
+		body    []byte
+		ptr     any
+		want    any
+		wantErr error
+	}{
+		{
+			name: "UTF-8 string",
+			body: []byte(text),
+			ptr:  &Text{},
+			want: &Text{Body: text},
+		},
+		{
+			name: "Binary bytes",
+			body: binary,
+			ptr:  &Binary{},
+			want: &Binary{Body: binary},
+		},
+		{
+			name: "Pointer to string",
+			body: []byte("text"),
+			ptr:  &Pointer{},
+			want: &Pointer{Body: &short},
+		},
+		{
+			name: "Untagged field",
+			body: []byte("text"),
+			ptr:  &Untagged{},
+			want: &Untagged{},
+		},
+		{
+			name: "String",
+			body: []byte(text),
+			ptr:  new(string),
+			want: &text,
+		},
+		{
+			name: "Bytes",
+			body: binary,
+			ptr:  new([]byte),
+			want: &binary,
+		},
+		{
+			name:    "Invalid field type",
+			body:    []byte("text"),
+			ptr:     &Invalid{},
+			wantErr: rerr.NotSupported,
+		},
+		{
+			name:    "Invalid ptr type",
+			body:    []byte("text"),
+			ptr:     new(int),
+			wantErr: rerr.NotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, requestURL, bytes.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", ContentTypePlainText)
+
+			err = NewPlainText().Decode(req, tt.ptr)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, tt.ptr)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 const (
 	// ContentTypeXML content-type header for xml decoder.
 	ContentTypeXML = "application/xml"
+	tagXML         = "xml"
 )
 
 // XMLOptionsFunc function for setting xml options.
@@ -45,6 +46,11 @@ func (x *XML) Decode(r *http.Request, ptr any) error {
 	return nil
 }
 
+// Tags returns struct tags used by decoder.
+func (x *XML) Tags() []string {
+	return []string{tagXML}
+}
+
 // ContentType returns content-type header value.
 func (x *XML) ContentType() string {
 	return x.contentType
@@ -12,6 +12,7 @@ func TestNewXML(t *testing.T) {
 	x := NewXML()
 	require.NotNil(t, x)
 	require.Equal(t, ContentTypeXML, x.ContentType())
+	require.Equal(t, []string{"xml"}, x.Tags())
 
 	x = NewXML(WithContentType[*XML]("test"))
 	require.NotNil(t, x)
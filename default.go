@@ -0,0 +1,95 @@
+package roamer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/value"
+)
+
+const (
+	// TagDefault default tag, value is set into a field which was not filled from request, e.g. `default:"10"`.
+	//
+	// Value prefixed with `$` is a name of default func, e.g. `default:"$uuid"`, `$$` escapes literal `$`.
+	TagDefault = "default"
+	// DefaultFuncUUID name of default func generating random UUID v4.
+	DefaultFuncUUID   = "uuid"
+	defaultFuncPrefix = "$"
+)
+
+// DefaultFunc returns default value of a field.
+type DefaultFunc = func() (string, error)
+
+// DefaultFuncs default funcs by names.
+type DefaultFuncs map[string]DefaultFunc
+
+var defaultFuncs = DefaultFuncs{
+	DefaultFuncUUID: newUUID,
+}
+
+// setDefault sets value of default tag into a zero field.
+func (r *Roamer) setDefault(fieldType *reflect.StructField, fieldValue reflect.Value) error {
+	str, ok := fieldType.Tag.Lookup(TagDefault)
+	if !ok || !fieldValue.IsZero() {
+		return nil
+	}
+
+	str, err := r.defaultValue(str)
+	if err != nil {
+		return errors.WithStack(rerr.ParseError{Field: fieldType.Name, Err: err})
+	}
+
+	if err := value.Set(fieldValue, str); err != nil {
+		return errors.Wrapf(err, "set default `%s` value to field `%s`", str, fieldType.Name)
+	}
+
+	return nil
+}
+
+// defaultValue returns value of default tag calling default func if needed.
+func (r *Roamer) defaultValue(str string) (string, error) {
+	name, ok := strings.CutPrefix(str, defaultFuncPrefix)
+	if !ok || strings.HasPrefix(name, defaultFuncPrefix) {
+		return name, nil
+	}
+
+	fn, ok := r.defaultFuncs[name]
+	if !ok {
+		return "", errors.Wrapf(rerr.NotSupported, "default func `%s`", name)
+	}
+
+	generated, err := fn()
+	if err != nil {
+		return "", errors.WithMessagef(err, "call default func `%s`", name)
+	}
+
+	return generated, nil
+}
+
+// newUUID returns random UUID v4.
+func newUUID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+
+	return string(buf[:]), nil
+}
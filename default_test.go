@@ -0,0 +1,104 @@
+package roamer
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestRoamer_Parse_Default(t *testing.T) {
+	type Data struct {
+		RequestID string `header:"X-Request-ID" default:"$uuid"`
+		Limit     int    `query:"limit" default:"10"`
+		Currency  string `query:"currency" default:"$$USD"`
+		Source    string `default:"$source"`
+	}
+
+	r := NewRoamer(
+		WithParsers(parser.NewHeader(), parser.NewQuery()),
+		WithDefaultFuncs(DefaultFuncs{
+			"source": func() (string, error) {
+				return "api", nil
+			},
+		}),
+	)
+
+	t.Run("Header present", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "test.com?limit=5&currency=EUR", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Request-ID", "request-1")
+
+		var d Data
+		err = r.Parse(req, &d)
+		require.NoError(t, err)
+		require.Equal(t, Data{RequestID: "request-1", Limit: 5, Currency: "EUR", Source: "api"}, d)
+	})
+
+	t.Run("Header absent", func(t *testing.T) {
+		ids := make(map[string]struct{})
+		for range 2 {
+			req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+			require.NoError(t, err)
+
+			var d Data
+			err = r.Parse(req, &d)
+			require.NoError(t, err)
+			require.Regexp(t, uuidRegexp, d.RequestID)
+			require.Equal(t, 10, d.Limit)
+			require.Equal(t, "$USD", d.Currency)
+			require.Equal(t, "api", d.Source)
+
+			ids[d.RequestID] = struct{}{}
+		}
+
+		require.Len(t, ids, 2, "uuid is generated for every request")
+	})
+
+	t.Run("Filled field", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+		require.NoError(t, err)
+
+		d := Data{RequestID: "filled"}
+		err = r.Parse(req, &d)
+		require.NoError(t, err)
+		require.Equal(t, "filled", d.RequestID)
+	})
+
+	t.Run("Unknown default func", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+		require.NoError(t, err)
+
+		var d Data
+		err = NewRoamer(WithParsers(parser.NewHeader(), parser.NewQuery())).Parse(req, &d)
+		require.ErrorIs(t, err, rerr.NotSupported)
+
+		parseErr, ok := IsParseError(err)
+		require.True(t, ok)
+		require.Equal(t, "Source", parseErr.Field)
+	})
+
+	t.Run("Invalid default value", func(t *testing.T) {
+		type Data struct {
+			Limit int `query:"limit" default:"ten"`
+		}
+
+		req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+		require.NoError(t, err)
+
+		var d Data
+		err = r.Parse(req, &d)
+		require.Error(t, err)
+	})
+}
+
+func TestNewUUID(t *testing.T) {
+	id, err := newUUID()
+	require.NoError(t, err)
+	require.Regexp(t, uuidRegexp, id)
+}
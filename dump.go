@@ -0,0 +1,181 @@
+package roamer
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+const tagJSON = "json"
+
+var (
+	typeJSONMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	typeTextMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	typeTime          = reflect.TypeOf(time.Time{})
+)
+
+// DumpParsed serializes parsed struct into json, names of fields are taken from tag of decoder,
+// e.g. `json`, `xml` or `form`, to echo parsed request in debug endpoints and tests.
+//
+// Struct is marshalled by encoding/json as is for `json` tag. For other tags name of a field is the tag name
+// or the field name if tag has no name, fields with `-` name are skipped and `omitempty` option is respected.
+// Nested structs are dumped by the same tag, fields of embedded structs without tag are promoted.
+//
+// dest must be a struct or pointer to a struct.
+func DumpParsed(dest any, tag string) ([]byte, error) {
+	v := reflect.ValueOf(dest)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, errors.WithStack(rerr.NilValue)
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, errors.Wrapf(rerr.NotSupported, "%T", dest)
+	}
+
+	var data []byte
+	var err error
+	if tag == tagJSON {
+		data, err = json.Marshal(dest)
+	} else {
+		data, err = json.Marshal(dumpStruct(v, tag))
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "marshal parsed struct")
+	}
+
+	return data, nil
+}
+
+// dumpStruct returns values of exported fields of struct by names of tag,
+// fields of the struct take precedence over promoted ones.
+func dumpStruct(v reflect.Value, tag string) map[string]any {
+	t := v.Type()
+	fields := make(map[string]any, t.NumField())
+
+	var promoted []map[string]any
+	for i := range t.NumField() {
+		field := t.Field(i)
+		fieldValue := v.Field(i)
+
+		tagValue, hasTag := field.Tag.Lookup(tag)
+		name, opts, _ := strings.Cut(tagValue, ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && !hasTag {
+			if embedded, ok := dumpEmbedded(fieldValue, tag); ok {
+				promoted = append(promoted, embedded)
+			}
+
+			continue
+		}
+
+		if !field.IsExported() || !fieldValue.CanInterface() {
+			continue
+		}
+
+		if hasOption(opts, "omitempty") && fieldValue.IsZero() {
+			continue
+		}
+
+		if len(name) == 0 {
+			name = field.Name
+		}
+
+		fields[name] = dumpValue(fieldValue, tag)
+	}
+
+	for _, embedded := range promoted {
+		for name, value := range embedded {
+			if _, ok := fields[name]; !ok {
+				fields[name] = value
+			}
+		}
+	}
+
+	return fields
+}
+
+// dumpEmbedded returns fields of embedded struct or pointer to struct.
+func dumpEmbedded(v reflect.Value, tag string) (map[string]any, bool) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, false
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	return dumpStruct(v, tag), true
+}
+
+// dumpValue returns value of field, structs which are not marshalers are dumped by tag.
+func dumpValue(v reflect.Value, tag string) any {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if !isMarshaler(v.Type()) {
+			return dumpStruct(v, tag)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+
+		if elem := v.Type().Elem(); elem.Kind() == reflect.Struct && !isMarshaler(elem) {
+			values := make([]any, v.Len())
+			for i := range values {
+				values[i] = dumpValue(v.Index(i), tag)
+			}
+
+			return values
+		}
+	}
+
+	return v.Interface()
+}
+
+// isMarshaler reports whether values of type are marshalled by themselves, e.g. time.Time.
+func isMarshaler(t reflect.Type) bool {
+	if t == typeTime {
+		return true
+	}
+
+	pt := reflect.PointerTo(t)
+
+	return t.Implements(typeJSONMarshaler) || pt.Implements(typeJSONMarshaler) ||
+		t.Implements(typeTextMarshaler) || pt.Implements(typeTextMarshaler)
+}
+
+// hasOption reports whether comma-separated options of tag value contain option.
+func hasOption(opts, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if strings.TrimSpace(opt) == option {
+			return true
+		}
+	}
+
+	return false
+}
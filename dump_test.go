@@ -0,0 +1,130 @@
+package roamer
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slipros/roamer/decoder"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpParsed(t *testing.T) {
+	type Data struct {
+		Name   string `json:"name"`
+		UserID int    `json:"user_id" query:"user_id"`
+		Skip   string `json:"-" header:"X-Skip"`
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "test.com?user_id=7", strings.NewReader(`{"name":"test"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+	req.Header.Set("X-Skip", "secret")
+
+	var d Data
+	err = NewRoamer(
+		WithDecoders(decoder.NewJSON()),
+		WithParsers(parser.NewQuery(), parser.NewHeader()),
+	).Parse(req, &d)
+	require.NoError(t, err)
+
+	data, err := DumpParsed(&d, "json")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"test","user_id":7}`, string(data))
+
+	data, err = DumpParsed(d, "json")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"test","user_id":7}`, string(data))
+
+	tests := []struct {
+		name string
+		dest any
+		want error
+	}{
+		{
+			name: "nil pointer",
+			dest: (*Data)(nil),
+			want: rerr.NilValue,
+		},
+		{
+			name: "not a struct",
+			dest: []string{"test"},
+			want: rerr.NotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := DumpParsed(tt.dest, "json")
+			require.ErrorIs(t, err, tt.want)
+			require.Nil(t, data)
+		})
+	}
+}
+
+func TestDumpParsed_DecoderTag(t *testing.T) {
+	type Address struct {
+		City string `form:"city" xml:"city"`
+	}
+
+	type Base struct {
+		ID int `form:"id" xml:"id"`
+	}
+
+	type Data struct {
+		Base
+		Name      string     `form:"user_name" xml:"name" json:"name"`
+		Note      string     `form:"note,omitempty" xml:"note,omitempty"`
+		Secret    string     `form:"-" xml:"-"`
+		Untagged  int        `json:"untagged"`
+		Address   *Address   `form:"address" xml:"address"`
+		Addresses []Address  `form:"addresses" xml:"addresses"`
+		CreatedAt time.Time  `form:"created_at" xml:"created_at"`
+		Deleted   *time.Time `form:"deleted,omitempty"`
+	}
+
+	createdAt := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(url.Values{
+		"user_name":  {"test"},
+		"created_at": {createdAt.Format(time.RFC3339)},
+	}.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", decoder.ContentTypeFormURL)
+
+	var d Data
+	err = NewRoamer(WithDecoders(decoder.NewFormURL())).Parse(req, &d)
+	require.NoError(t, err)
+
+	d.ID = 1
+	d.Secret = "secret"
+	d.Untagged = 2
+	d.Address = &Address{City: "Paris"}
+	d.Addresses = []Address{{City: "Rome"}}
+
+	data, err := DumpParsed(&d, "form")
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"id": 1,
+		"user_name": "test",
+		"Untagged": 2,
+		"address": {"city": "Paris"},
+		"addresses": [{"city": "Rome"}],
+		"created_at": "2024-03-10T12:00:00Z"
+	}`, string(data))
+
+	data, err = DumpParsed(&d, "xml")
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"id": 1,
+		"name": "test",
+		"Untagged": 2,
+		"address": {"city": "Paris"},
+		"addresses": [{"city": "Rome"}],
+		"created_at": "2024-03-10T12:00:00Z",
+		"Deleted": null
+	}`, string(data))
+}
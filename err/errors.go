@@ -18,10 +18,34 @@ var (
 	NilValue = errors.New("value is nil")
 	// NotPtr not a pointer.
 	NotPtr = errors.New("not a ptr")
+	// NotSettable value is not settable.
+	NotSettable = errors.New("value is not settable")
 	// NotSupported type is not supported.
 	NotSupported = errors.New("not supported type")
 	// FieldIndexOutOfBounds field index out of bounds.
 	FieldIndexOutOfBounds = errors.New("field index out of bounds")
+	// OutOfRange value is out of range.
+	OutOfRange = errors.New("value out of range")
+	// TooManyItems amount of items exceeds limit.
+	TooManyItems = errors.New("too many items")
+	// InvalidTag tag value is invalid.
+	InvalidTag = errors.New("invalid tag value")
+	// NotOneOf value is not one of allowed values.
+	NotOneOf = errors.New("value is not one of allowed values")
+	// NotAllowed value is not allowed.
+	NotAllowed = errors.New("value is not allowed")
+	// UnmappedValue value is missing in mapping table.
+	UnmappedValue = errors.New("unmapped value")
+	// RequestTooLarge request body exceeds size limit.
+	RequestTooLarge = errors.New("request too large")
+	// BodyNotPreserved request body is not preserved.
+	BodyNotPreserved = errors.New("body is not preserved")
+	// MissingValue required value is missing.
+	MissingValue = errors.New("value is missing")
+	// InvalidFormat value has invalid format.
+	InvalidFormat = errors.New("invalid format")
+	// UnsupportedContentType there is no decoder of request content type.
+	UnsupportedContentType = errors.New("unsupported content type")
 )
 
 // DecodeError decode error.
@@ -34,6 +58,42 @@ func (d DecodeError) Error() string {
 	return d.Err.Error()
 }
 
+// Unwrap returns underlying error.
+func (d DecodeError) Unwrap() error {
+	return d.Err
+}
+
+// ParseError parse error of struct field.
+type ParseError struct {
+	Field string
+	Err   error
+}
+
+// Error returns string.
+func (p ParseError) Error() string {
+	return "field `" + p.Field + "`: " + p.Err.Error()
+}
+
+// Unwrap returns underlying error.
+func (p ParseError) Unwrap() error {
+	return p.Err
+}
+
+// ValidationError validation error of parsed value.
+type ValidationError struct {
+	Err error
+}
+
+// Error returns string.
+func (v ValidationError) Error() string {
+	return "validation: " + v.Err.Error()
+}
+
+// Unwrap returns underlying error.
+func (v ValidationError) Unwrap() error {
+	return v.Err
+}
+
 // SliceIterationError slice iteration error.
 type SliceIterationError struct {
 	Err   error
@@ -45,6 +105,11 @@ func (s SliceIterationError) Error() string {
 	return fmt.Sprintf("slice element with index %d: %v", s.Index, s.Err)
 }
 
+// Unwrap returns underlying error.
+func (s SliceIterationError) Unwrap() error {
+	return s.Err
+}
+
 // FormatterNotFound not found formatter error.
 type FormatterNotFound struct {
 	Tag       string
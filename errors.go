@@ -16,3 +16,15 @@ func IsSliceIterationError(err error) (rerr.SliceIterationError, bool) {
 	var iterationErr rerr.SliceIterationError
 	return iterationErr, errors.As(err, &iterationErr)
 }
+
+// IsParseError checks the error for belonging to parse error.
+func IsParseError(err error) (rerr.ParseError, bool) {
+	var parseErr rerr.ParseError
+	return parseErr, errors.As(err, &parseErr)
+}
+
+// IsValidationError checks the error for belonging to validation error.
+func IsValidationError(err error) (rerr.ValidationError, bool) {
+	var validationErr rerr.ValidationError
+	return validationErr, errors.As(err, &validationErr)
+}
@@ -87,3 +87,14 @@ func TestIsSliceIterationError(t *testing.T) {
 		})
 	}
 }
+
+func TestIsValidationError(t *testing.T) {
+	errInvalid := errors.New("invalid")
+
+	got, ok := IsValidationError(errors.WithStack(rerr.ValidationError{Err: errInvalid}))
+	require.True(t, ok)
+	require.Equal(t, rerr.ValidationError{Err: errInvalid}, got)
+
+	_, ok = IsValidationError(errInvalid)
+	require.False(t, ok)
+}
@@ -0,0 +1,102 @@
+package roamer
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/slipros/roamer/parser"
+)
+
+// FieldNameMapper maps name of struct field to a key of request data.
+type FieldNameMapper func(name string) string
+
+// SnakeCase maps field name to snake case, e.g. `UserID` to `user_id`.
+func SnakeCase(name string) string {
+	return strings.ToLower(strings.Join(splitFieldName(name), "_"))
+}
+
+// KebabCase maps field name to kebab case, e.g. `UserID` to `user-id`.
+func KebabCase(name string) string {
+	return strings.ToLower(strings.Join(splitFieldName(name), "-"))
+}
+
+// CamelCase maps field name to camel case, e.g. `UserID` to `userId`.
+func CamelCase(name string) string {
+	words := splitFieldName(name)
+
+	var b strings.Builder
+	b.Grow(len(name))
+
+	for i, w := range words {
+		w = strings.ToLower(w)
+		if i > 0 {
+			first, size := utf8.DecodeRuneInString(w)
+			b.WriteRune(unicode.ToUpper(first))
+			w = w[size:]
+		}
+
+		b.WriteString(w)
+	}
+
+	return b.String()
+}
+
+// splitFieldName splits field name into words keeping acronyms together, e.g. `HTTPServerID` to `HTTP`, `Server`, `ID`,
+// underscores separate words and are dropped.
+func splitFieldName(name string) []string {
+	runes := []rune(name)
+
+	var (
+		words []string
+		start int
+	)
+
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+
+		isBoundary := unicode.IsUpper(cur) &&
+			(unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])))
+		if cur == '_' || isBoundary {
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+
+			start = i
+			if cur == '_' {
+				start++
+			}
+		}
+	}
+
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
+
+// implicitTag returns tag of field without tags with key derived by field name mapper for every parser.
+func (r *Roamer) implicitTag(name string) reflect.StructTag {
+	key := r.fieldNameMapper(name)
+
+	var b strings.Builder
+	for _, tag := range r.parserTags {
+		if tag == parser.TagMeta {
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+
+		b.WriteString(tag)
+		b.WriteString(`:"`)
+		b.WriteString(key)
+		b.WriteByte('"')
+	}
+
+	return reflect.StructTag(b.String())
+}
@@ -0,0 +1,155 @@
+package roamer
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldNameMappers(t *testing.T) {
+	tests := []struct {
+		name  string
+		snake string
+		kebab string
+		camel string
+	}{
+		{name: "ID", snake: "id", kebab: "id", camel: "id"},
+		{name: "UserID", snake: "user_id", kebab: "user-id", camel: "userId"},
+		{name: "HTTPServerName", snake: "http_server_name", kebab: "http-server-name", camel: "httpServerName"},
+		{name: "Page2Size", snake: "page2_size", kebab: "page2-size", camel: "page2Size"},
+		{name: "Already_Snake", snake: "already_snake", kebab: "already-snake", camel: "alreadySnake"},
+		{name: "Foo__Bar", snake: "foo_bar", kebab: "foo-bar", camel: "fooBar"},
+		{name: "Trailing_", snake: "trailing", kebab: "trailing", camel: "trailing"},
+		{name: "NameÜber", snake: "name_über", kebab: "name-über", camel: "nameÜber"},
+		{name: "Name_élan", snake: "name_élan", kebab: "name-élan", camel: "nameÉlan"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.snake, SnakeCase(tt.name))
+			require.Equal(t, tt.kebab, KebabCase(tt.name))
+			require.Equal(t, tt.camel, CamelCase(tt.name))
+		})
+	}
+}
+
+func TestRoamer_Parse_FieldNameMapper(t *testing.T) {
+	type Data struct {
+		UserID   int
+		PageSize int
+		Sort     string `query:"order"`
+		ignored  string
+	}
+
+	tests := []struct {
+		name   string
+		mapper FieldNameMapper
+		query  string
+		want   Data
+	}{
+		{
+			name:   "snake case",
+			mapper: SnakeCase,
+			query:  "user_id=1&page_size=20&order=asc",
+			want:   Data{UserID: 1, PageSize: 20, Sort: "asc"},
+		},
+		{
+			name:   "camel case",
+			mapper: CamelCase,
+			query:  "userId=1&pageSize=20&order=asc",
+			want:   Data{UserID: 1, PageSize: 20, Sort: "asc"},
+		},
+		{
+			name:  "no mapper",
+			query: "user_id=1&page_size=20&order=asc",
+			want:  Data{Sort: "asc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?"+tt.query+"&ignored=1", nil)
+			require.NoError(t, err)
+
+			opts := []OptionsFunc{WithParsers(parser.NewQuery())}
+			if tt.mapper != nil {
+				opts = append(opts, WithFieldNameMapper(tt.mapper))
+			}
+
+			var d Data
+			err = NewRoamer(opts...).Parse(req, &d)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestRoamer_Parse_FieldNameMatcher(t *testing.T) {
+	type Data struct {
+		UserID    int    `query:"user_id"`
+		RequestID string `header:"X-Request-Id"`
+	}
+
+	tests := []struct {
+		name    string
+		matcher parser.FieldNameMatcher
+		query   string
+		header  string
+		want    Data
+	}{
+		{
+			name:    "exact",
+			matcher: parser.LooseMatch,
+			query:   "user_id=1&userId=2",
+			header:  "X-Request-Id",
+			want:    Data{UserID: 1, RequestID: "r1"},
+		},
+		{
+			name:    "camel case",
+			matcher: parser.LooseMatch,
+			query:   "userId=2",
+			header:  "x_request_id",
+			want:    Data{UserID: 2, RequestID: "r1"},
+		},
+		{
+			name:    "upper case",
+			matcher: parser.LooseMatch,
+			query:   "USERID=3",
+			header:  "XREQUESTID",
+			want:    Data{UserID: 3, RequestID: "r1"},
+		},
+		{
+			name:    "case insensitive",
+			matcher: strings.EqualFold,
+			query:   "USER_ID=4&userId=5",
+			want:    Data{UserID: 4},
+		},
+		{
+			name:   "no matcher",
+			query:  "userId=2",
+			header: "x_request_id",
+			want:   Data{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?"+tt.query, nil)
+			require.NoError(t, err)
+
+			if len(tt.header) > 0 {
+				req.Header[tt.header] = []string{"r1"}
+			}
+
+			opts := []OptionsFunc{WithParsers(parser.NewQuery(), parser.NewHeader())}
+			if tt.matcher != nil {
+				opts = append(opts, WithFieldNameMatcher(tt.matcher))
+			}
+
+			var d Data
+			err = NewRoamer(opts...).Parse(req, &d)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package roamer
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// normalizeFieldName returns name in lower case without underscores and dashes,
+// so `is_admin` and `IsAdmin` are the same names.
+func normalizeFieldName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' {
+			return -1
+		}
+
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// isFieldAllowed reports whether field can be filled from request.
+func (r *Roamer) isFieldAllowed(name string) bool {
+	if r.allowedFields == nil && r.protectedFields == nil {
+		return true
+	}
+
+	name = normalizeFieldName(name)
+	if _, ok := r.protectedFields[name]; ok {
+		return false
+	}
+
+	if r.allowedFields == nil {
+		return true
+	}
+
+	_, ok := r.allowedFields[name]
+	return ok
+}
+
+// isFieldProtected reports whether field is listed as protected.
+func (r *Roamer) isFieldProtected(name string) bool {
+	_, ok := r.protectedFields[normalizeFieldName(name)]
+	return ok
+}
+
+// completeDeniedField sets default value of field which is not allowed to be filled from request,
+// then formats and validates it like other fields.
+func (r *Roamer) completeDeniedField(fieldType *reflect.StructField, fieldValue reflect.Value, ptr any) error {
+	if err := r.setDefault(fieldType, fieldValue); err != nil {
+		return err
+	}
+
+	return r.completeField(fieldType, fieldValue, ptr)
+}
+
+// decode decodes body into ptr, only allowed and not protected fields of struct are filled.
+//
+// Fields promoted from embedded structs are filtered by their own names, the same as fields of ptr.
+func (r *Roamer) decode(d Decoder, req *http.Request, ptr any) error {
+	r.markUsed(ComponentDecoder, d.ContentType())
+
+	v := reflect.Indirect(reflect.ValueOf(ptr))
+	if (r.allowedFields == nil && r.protectedFields == nil) || v.Kind() != reflect.Struct {
+		return d.Decode(req, ptr)
+	}
+
+	// decode into a copy without values of disallowed and protected fields,
+	// so decoders can't write through pointers of disallowed fields either.
+	tmp := reflect.New(v.Type())
+	tmp.Elem().Set(v)
+	r.zeroDisallowed(tmp.Elem())
+
+	if err := d.Decode(req, tmp.Interface()); err != nil {
+		return err
+	}
+
+	r.copyAllowed(v, tmp.Elem())
+
+	return nil
+}
+
+// isEmbeddedStruct reports whether field is an embedded struct or pointer to struct, which is not protected,
+// so its promoted fields are filtered separately.
+func (r *Roamer) isEmbeddedStruct(field *reflect.StructField) bool {
+	if !field.Anonymous || r.isFieldProtected(field.Name) {
+		return false
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct
+}
+
+// zeroDisallowed zeroes disallowed and protected fields of struct including promoted ones,
+// pointers to embedded structs are replaced with pointers to copies.
+func (r *Roamer) zeroDisallowed(v reflect.Value) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		fieldValue := v.Field(i)
+
+		if r.isEmbeddedStruct(&field) {
+			if field.Type.Kind() != reflect.Pointer {
+				r.zeroDisallowed(fieldValue)
+				continue
+			}
+
+			if fieldValue.IsNil() || !fieldValue.CanSet() {
+				continue
+			}
+
+			embedded := reflect.New(field.Type.Elem())
+			embedded.Elem().Set(fieldValue.Elem())
+			fieldValue.Set(embedded)
+			r.zeroDisallowed(embedded.Elem())
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if !r.isFieldAllowed(field.Name) {
+			fieldValue.SetZero()
+		}
+	}
+}
+
+// copyAllowed copies allowed and not protected fields of struct including promoted ones from src to dst.
+func (r *Roamer) copyAllowed(dst, src reflect.Value) {
+	t := dst.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		dstField, srcField := dst.Field(i), src.Field(i)
+
+		if r.isEmbeddedStruct(&field) {
+			if field.Type.Kind() != reflect.Pointer {
+				r.copyAllowed(dstField, srcField)
+				continue
+			}
+
+			if srcField.IsNil() || !dstField.CanSet() {
+				continue
+			}
+
+			if dstField.IsNil() {
+				dstField.Set(reflect.New(field.Type.Elem()))
+			}
+
+			r.copyAllowed(dstField.Elem(), srcField.Elem())
+
+			continue
+		}
+
+		if field.IsExported() && r.isFieldAllowed(field.Name) {
+			dstField.Set(srcField)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package roamer
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/slipros/roamer/decoder"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoamer_Parse_AllowedFields(t *testing.T) {
+	type Data struct {
+		Name    string  `json:"name"`
+		Email   string  `query:"email"`
+		IsAdmin bool    `json:"is_admin"`
+		Role    string  `query:"role"`
+		Balance *int    `json:"balance"`
+		Note    *string `json:"note"`
+	}
+
+	balance := 100
+
+	req, err := http.NewRequest(http.MethodPost, "test.com?email=a@test.com&role=admin",
+		strings.NewReader(`{"name":"test","is_admin":true,"balance":1000000,"note":"new"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+	r := NewRoamer(
+		WithDecoders(decoder.NewJSON()),
+		WithParsers(parser.NewQuery()),
+		WithAllowedFields("name", "Email", "note"),
+	)
+
+	d := Data{Balance: &balance}
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+
+	require.Equal(t, "test", d.Name)
+	require.Equal(t, "a@test.com", d.Email)
+	require.NotNil(t, d.Note)
+	require.Equal(t, "new", *d.Note)
+	require.False(t, d.IsAdmin)
+	require.Empty(t, d.Role)
+	require.Equal(t, &balance, d.Balance)
+	require.Equal(t, 100, balance, "disallowed pointer is not written through")
+}
+
+func TestRoamer_Parse_AllowedFields_Validation(t *testing.T) {
+	type Data struct {
+		Name  string `query:"name"`
+		ID    int    `query:"id" required:"true"`
+		Limit int    `query:"limit" default:"10" range:"1..100"`
+	}
+
+	r := NewRoamer(WithParsers(parser.NewQuery()), WithAllowedFields("name"))
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?name=test&id=1&limit=1000", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.ErrorIs(t, err, rerr.MissingValue)
+
+	parseErr, ok := IsParseError(err)
+	require.True(t, ok)
+	require.Equal(t, "ID", parseErr.Field)
+
+	d = Data{ID: 7}
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Name: "test", ID: 7, Limit: 10}, d, "not allowed fields get defaults only")
+}
+
+func TestRoamer_Parse_ProtectedFields(t *testing.T) {
+	type Data struct {
+		ID      int    `json:"id"`
+		Name    string `json:"name"`
+		IsAdmin bool   `query:"is_admin"`
+		Email   string `query:"email"`
+	}
+
+	tests := []struct {
+		name string
+		opts []OptionsFunc
+		want Data
+	}{
+		{
+			name: "protected",
+			opts: []OptionsFunc{WithProtectedFields("is_admin", "id")},
+			want: Data{Name: "test", Email: "a@test.com"},
+		},
+		{
+			name: "protected takes precedence over allowed",
+			opts: []OptionsFunc{WithAllowedFields("is_admin", "name"), WithProtectedFields("is_admin")},
+			want: Data{Name: "test"},
+		},
+		{
+			name: "not protected",
+			want: Data{ID: 1, Name: "test", IsAdmin: true, Email: "a@test.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "test.com?is_admin=true&email=a@test.com",
+				strings.NewReader(`{"id":1,"name":"test"}`))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+			opts := append([]OptionsFunc{WithDecoders(decoder.NewJSON()), WithParsers(parser.NewQuery())}, tt.opts...)
+
+			var d Data
+			err = NewRoamer(opts...).Parse(req, &d)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestRoamer_Parse_ProtectedFields_Validation(t *testing.T) {
+	type Base struct {
+		ID      int  `json:"id"`
+		IsAdmin bool `json:"is_admin"`
+	}
+
+	type Data struct {
+		Base
+		Name string `json:"name"`
+		Role string `json:"role" required:"true"`
+		Plan string `json:"plan" default:"free"`
+	}
+
+	newRequest := func(t *testing.T) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com",
+			strings.NewReader(`{"id":1,"is_admin":true,"name":"test","role":"admin","plan":"pro"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+		return req
+	}
+
+	r := NewRoamer(WithDecoders(decoder.NewJSON()), WithProtectedFields("is_admin", "role", "plan"))
+
+	var d Data
+	err := r.Parse(newRequest(t), &d)
+	require.ErrorIs(t, err, rerr.MissingValue)
+
+	parseErr, ok := IsParseError(err)
+	require.True(t, ok)
+	require.Equal(t, "Role", parseErr.Field)
+
+	d = Data{Role: "user"}
+	err = r.Parse(newRequest(t), &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Base: Base{ID: 1}, Name: "test", Role: "user", Plan: "free"}, d)
+}
+
+func TestRoamer_Parse_ProtectedFields_Embedded(t *testing.T) {
+	type Base struct {
+		ID      int  `json:"id"`
+		IsAdmin bool `json:"is_admin"`
+	}
+
+	type audit struct {
+		Note   string `json:"note"`
+		Source string `json:"source"`
+	}
+
+	type PtrData struct {
+		*Base
+		Name string `json:"name"`
+	}
+
+	const body = `{"id":1,"is_admin":true,"note":"new","source":"api","name":"test"}`
+
+	newRequest := func(t *testing.T) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+		return req
+	}
+
+	type Flat struct {
+		Base
+		audit
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name string
+		opts []OptionsFunc
+		want Flat
+	}{
+		{
+			name: "protected promoted field",
+			opts: []OptionsFunc{WithProtectedFields("is_admin")},
+			want: Flat{Base: Base{ID: 1}, audit: audit{Note: "new", Source: "api"}, Name: "test"},
+		},
+		{
+			name: "allowed promoted fields",
+			opts: []OptionsFunc{WithAllowedFields("id", "note", "name")},
+			want: Flat{Base: Base{ID: 1}, audit: audit{Note: "new"}, Name: "test"},
+		},
+		{
+			name: "protected embedded struct",
+			opts: []OptionsFunc{WithProtectedFields("base")},
+			want: Flat{audit: audit{Note: "new", Source: "api"}, Name: "test"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Flat
+			err := NewRoamer(append([]OptionsFunc{WithDecoders(decoder.NewJSON())}, tt.opts...)...).
+				Parse(newRequest(t), &d)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+
+	t.Run("embedded pointers", func(t *testing.T) {
+		r := NewRoamer(WithDecoders(decoder.NewJSON()), WithProtectedFields("is_admin"))
+
+		var d PtrData
+		err := r.Parse(newRequest(t), &d)
+		require.NoError(t, err)
+		require.NotNil(t, d.Base)
+		require.Equal(t, PtrData{Base: &Base{ID: 1}, Name: "test"}, d)
+
+		base := &Base{ID: 5}
+		d = PtrData{Base: base}
+		err = r.Parse(newRequest(t), &d)
+		require.NoError(t, err)
+		require.Same(t, base, d.Base)
+		require.Equal(t, Base{ID: 1}, *base, "protected field is not written through pointer")
+	})
+}
@@ -0,0 +1,117 @@
+package roamer
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+const (
+	// TagFormat format tag.
+	TagFormat = "format"
+	// FormatUUID format of UUID string, e.g. `format:"uuid"`.
+	FormatUUID = "uuid"
+	// FormatLen format of string with bounded length in runes, e.g. `format:"len=8..64"`.
+	FormatLen = "len"
+)
+
+// validateFormat checks that string field value matches format declared by format tag.
+//
+// Empty values are not validated. Format tag is shared with OpenAPI annotations, e.g. `format:"date-time"`,
+// so unknown formats and fields which are not strings are ignored.
+func validateFormat(tag reflect.StructTag, fieldValue reflect.Value) error {
+	tagValue, ok := tag.Lookup(TagFormat)
+	if !ok {
+		return nil
+	}
+
+	if fieldValue.Kind() == reflect.Pointer {
+		if fieldValue.IsNil() {
+			return nil
+		}
+
+		fieldValue = fieldValue.Elem()
+	}
+
+	name, arg, _ := strings.Cut(tagValue, "=")
+	if (name != FormatUUID && name != FormatLen) || fieldValue.Kind() != reflect.String {
+		return nil
+	}
+
+	str := fieldValue.String()
+	if len(str) == 0 {
+		return nil
+	}
+
+	switch name {
+	case FormatUUID:
+		if !isUUID(str) {
+			return errors.Wrapf(rerr.InvalidFormat, "`%s` is not uuid", str)
+		}
+	case FormatLen:
+		return checkLen(str, arg)
+	}
+
+	return nil
+}
+
+// checkLen checks that length of string in runes is within inclusive bounds `min..max`.
+func checkLen(str, bounds string) error {
+	low, high, found := strings.Cut(bounds, rangeSeparator)
+	if !found {
+		return errors.Wrapf(rerr.InvalidTag, "%s:%q", TagFormat, FormatLen+"="+bounds)
+	}
+
+	n := utf8.RuneCountInString(str)
+
+	if len(low) > 0 {
+		minLen, err := strconv.Atoi(low)
+		if err != nil {
+			return errors.Wrapf(rerr.InvalidTag, "%s:%q", TagFormat, FormatLen+"="+bounds)
+		}
+
+		if n < minLen {
+			return errors.Wrapf(rerr.InvalidFormat, "length %d is less than %d", n, minLen)
+		}
+	}
+
+	if len(high) > 0 {
+		maxLen, err := strconv.Atoi(high)
+		if err != nil {
+			return errors.Wrapf(rerr.InvalidTag, "%s:%q", TagFormat, FormatLen+"="+bounds)
+		}
+
+		if n > maxLen {
+			return errors.Wrapf(rerr.InvalidFormat, "length %d is greater than %d", n, maxLen)
+		}
+	}
+
+	return nil
+}
+
+// isUUID reports whether string is UUID in canonical form, e.g. `f47ac10b-58cc-4372-a567-0e02b2c3d479`.
+func isUUID(str string) bool {
+	if len(str) != 36 {
+		return false
+	}
+
+	for i := range len(str) {
+		c := str[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
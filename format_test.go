@@ -0,0 +1,124 @@
+package roamer
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     reflect.StructTag
+		value   any
+		wantErr error
+	}{
+		{
+			name:  "no tag",
+			tag:   `header:"Idempotency-Key"`,
+			value: "key",
+		},
+		{
+			name:  "uuid",
+			tag:   `format:"uuid"`,
+			value: "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		},
+		{
+			name:    "not uuid",
+			tag:     `format:"uuid"`,
+			value:   "f47ac10b-58cc-4372-a567-0e02b2c3d47z",
+			wantErr: rerr.InvalidFormat,
+		},
+		{
+			name:  "empty value",
+			tag:   `format:"uuid"`,
+			value: "",
+		},
+		{
+			name:  "nil pointer",
+			tag:   `format:"uuid"`,
+			value: (*string)(nil),
+		},
+		{
+			name:  "length in runes",
+			tag:   `format:"len=2..4"`,
+			value: "ключ",
+		},
+		{
+			name:    "too short",
+			tag:     `format:"len=5.."`,
+			value:   "ключ",
+			wantErr: rerr.InvalidFormat,
+		},
+		{
+			name:    "too long",
+			tag:     `format:"len=..3"`,
+			value:   "ключ",
+			wantErr: rerr.InvalidFormat,
+		},
+		{
+			name:    "invalid bounds",
+			tag:     `format:"len=3"`,
+			value:   "key",
+			wantErr: rerr.InvalidTag,
+		},
+		{
+			name:  "unknown format",
+			tag:   `format:"date-time"`,
+			value: "key",
+		},
+		{
+			name:  "not string field",
+			tag:   `format:"int64"`,
+			value: 1,
+		},
+		{
+			name:  "uuid of not string field",
+			tag:   `format:"uuid"`,
+			value: [16]byte{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFormat(tt.tag, reflect.ValueOf(tt.value))
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRoamer_Parse_Format_OpenAPI(t *testing.T) {
+	type Data struct {
+		When  string    `query:"when" format:"date-time"`
+		Count int64     `query:"count" format:"int64"`
+		At    time.Time `query:"at" format:"date-time"`
+		ID    string    `query:"id" format:"uuid"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet,
+		"test.com?when=2024-01-02T03:04:05Z&count=7&at=2024-01-02T03:04:05Z&id=f47ac10b-58cc-4372-a567-0e02b2c3d479", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, "2024-01-02T03:04:05Z", d.When)
+	require.Equal(t, int64(7), d.Count)
+	require.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), d.At)
+
+	req, err = http.NewRequest(http.MethodGet, "test.com?id=1", nil)
+	require.NoError(t, err)
+
+	d = Data{}
+	err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &d)
+	require.ErrorIs(t, err, rerr.InvalidFormat)
+}
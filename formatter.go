@@ -1,7 +1,12 @@
 package roamer
 
 import (
+	"net/http"
 	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
 )
 
 // Formatter is a formatter.
@@ -12,6 +17,16 @@ type Formatter interface {
 	Tag() string
 }
 
+// StringParser is a formatter which also parses string values of fields with its tag instead of default conversion,
+// e.g. formatter.Time parses `1700000000` of `time:"unix"` field as unix timestamp.
+//
+// ParseString returns false if value is not handled by the formatter, anchor is time which relative values
+// are added to, see WithRelativeTimeAnchor.
+type StringParser interface {
+	Formatter
+	ParseString(tag reflect.StructTag, str string, anchor time.Time) (any, bool, error)
+}
+
 // Formatters is a map of formatters where keys are tags for given formatters.
 type Formatters map[string]Formatter
 
@@ -24,3 +39,28 @@ func (ft Formatters) has(tag reflect.StructTag) bool {
 
 	return false
 }
+
+// parseString parses string value of field by registered formatters implementing StringParser.
+func (r *Roamer) parseString(req *http.Request, fieldType *reflect.StructField, str string) (any, bool, error) {
+	for tag, f := range r.formatters {
+		sp, ok := f.(StringParser)
+		if !ok {
+			continue
+		}
+
+		if _, ok := fieldType.Tag.Lookup(tag); !ok {
+			continue
+		}
+
+		v, ok, err := sp.ParseString(fieldType.Tag, str, r.timeAnchor(req))
+		if err != nil {
+			return nil, false, errors.WithStack(rerr.ParseError{Field: fieldType.Name, Err: err})
+		}
+
+		if ok {
+			return v, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
@@ -0,0 +1,96 @@
+package formatter
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+const (
+	// TagMapping mapping tag.
+	TagMapping = "map"
+)
+
+// MappingTables mapping tables by names, every table maps incoming values to new ones.
+type MappingTables map[string]map[string]string
+
+// Mapping is a formatter replacing values by mapping table named in tag, e.g. `map:"status"`.
+type Mapping struct {
+	tables MappingTables
+	strict bool
+}
+
+// NewMapping returns new mapping formatter.
+func NewMapping(tables MappingTables, opts ...MappingOptionsFunc) *Mapping {
+	m := Mapping{
+		tables: tables,
+	}
+
+	for _, opt := range opts {
+		opt(&m)
+	}
+
+	return &m
+}
+
+// Format replaces value by mapping table.
+//
+// Unmapped values are left unchanged unless strict mode is enabled.
+func (m *Mapping) Format(tag reflect.StructTag, ptr any) error {
+	tagValue, ok := tag.Lookup(TagMapping)
+	if !ok {
+		return nil
+	}
+
+	table, ok := m.tables[tagValue]
+	if !ok {
+		return errors.WithStack(rerr.FormatterNotFound{Tag: TagMapping, Formatter: tagValue})
+	}
+
+	switch v := ptr.(type) {
+	case *string:
+		mapped, err := m.mapValue(table, *v)
+		if err != nil {
+			return err
+		}
+
+		*v = mapped
+	case *[]string:
+		for i, s := range *v {
+			mapped, err := m.mapValue(table, s)
+			if err != nil {
+				return errors.WithStack(rerr.SliceIterationError{Err: err, Index: i})
+			}
+
+			(*v)[i] = mapped
+		}
+	default:
+		return errors.Wrapf(rerr.NotSupported, "%T", ptr)
+	}
+
+	return nil
+}
+
+// Tag returns working tag.
+func (m *Mapping) Tag() string {
+	return TagMapping
+}
+
+// mapValue maps value by table, empty values are not mapped.
+func (m *Mapping) mapValue(table map[string]string, value string) (string, error) {
+	if len(value) == 0 {
+		return value, nil
+	}
+
+	mapped, ok := table[value]
+	if ok {
+		return mapped, nil
+	}
+
+	if m.strict {
+		return "", errors.Wrapf(rerr.UnmappedValue, "`%s`", value)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,110 @@
+package formatter
+
+import (
+	"reflect"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMapping(t *testing.T) {
+	m := NewMapping(nil)
+	require.NotNil(t, m)
+	require.Equal(t, TagMapping, m.Tag())
+	require.False(t, m.strict)
+
+	m = NewMapping(nil, WithStrictMapping())
+	require.True(t, m.strict)
+}
+
+func TestMapping_Format(t *testing.T) {
+	tables := MappingTables{
+		"answer": {"y": "yes", "n": "no"},
+		"status": {"1": "open", "2": "closed"},
+	}
+
+	tests := []struct {
+		name    string
+		opts    []MappingOptionsFunc
+		tag     reflect.StructTag
+		value   any
+		want    any
+		wantErr error
+	}{
+		{
+			name:  "alias",
+			tag:   `map:"answer"`,
+			value: "y",
+			want:  "yes",
+		},
+		{
+			name:  "legacy status code",
+			tag:   `map:"status"`,
+			value: "2",
+			want:  "closed",
+		},
+		{
+			name:  "unknown value passes through",
+			tag:   `map:"answer"`,
+			value: "maybe",
+			want:  "maybe",
+		},
+		{
+			name:    "unknown value in strict mode",
+			opts:    []MappingOptionsFunc{WithStrictMapping()},
+			tag:     `map:"answer"`,
+			value:   "maybe",
+			wantErr: rerr.UnmappedValue,
+		},
+		{
+			name:  "empty value in strict mode",
+			opts:  []MappingOptionsFunc{WithStrictMapping()},
+			tag:   `map:"answer"`,
+			value: "",
+			want:  "",
+		},
+		{
+			name:  "slice",
+			tag:   `map:"answer"`,
+			value: []string{"y", "n", "x"},
+			want:  []string{"yes", "no", "x"},
+		},
+		{
+			name:    "slice in strict mode",
+			opts:    []MappingOptionsFunc{WithStrictMapping()},
+			tag:     `map:"answer"`,
+			value:   []string{"y", "x"},
+			wantErr: rerr.UnmappedValue,
+		},
+		{
+			name:  "no tag",
+			tag:   `query:"answer"`,
+			value: "y",
+			want:  "y",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ptr := reflect.New(reflect.TypeOf(tt.value))
+			ptr.Elem().Set(reflect.ValueOf(tt.value))
+
+			err := NewMapping(tables, tt.opts...).Format(tt.tag, ptr.Interface())
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, ptr.Elem().Interface())
+		})
+	}
+
+	t.Run("unknown table", func(t *testing.T) {
+		str := "y"
+		err := NewMapping(tables).Format(`map:"unknown"`, &str)
+
+		var notFound rerr.FormatterNotFound
+		require.ErrorAs(t, err, &notFound)
+	})
+}
@@ -0,0 +1,115 @@
+package formatter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+const (
+	// TagMask mask tag.
+	TagMask = "mask"
+	// MaskPartial mask formatter keeping the first and the last characters of string, e.g. `mask:"partial"`.
+	MaskPartial = "partial"
+	// MaskFull mask formatter replacing string with `***`, e.g. `mask:"full"`.
+	MaskFull = "full"
+	// MaskSHA256 mask formatter replacing string with hex encoded sha256 digest, e.g. `mask:"sha256"`.
+	MaskSHA256 = "sha256"
+
+	// maskFullValue replacement of fully masked string, it doesn't reveal length of string.
+	maskFullValue = "***"
+	// maskChar replacement of a hidden character of partially masked string.
+	maskChar = "*"
+	// maskPartialMinLength min length in runes of string partially masked, shorter strings are masked fully.
+	maskPartialMinLength = 5
+)
+
+// MaskFormatterFunc mask formatter func.
+type MaskFormatterFunc = func(string) string
+
+var defaultMaskFormatters = map[string]MaskFormatterFunc{
+	MaskPartial: maskPartial,
+	MaskFull: func(string) string {
+		return maskFullValue
+	},
+	MaskSHA256: func(str string) string {
+		sum := sha256.Sum256([]byte(str))
+		return hex.EncodeToString(sum[:])
+	},
+}
+
+// Mask is a formatter masking sensitive strings, e.g. for audit logs.
+//
+// It changes a field in place, so it is applied to a copy of parsed struct which is logged,
+// set by roamer.WithFormatters it masks parsed fields themselves. Empty strings are left unchanged.
+type Mask struct {
+	formatters map[string]MaskFormatterFunc
+}
+
+// NewMask returns new mask formatter.
+func NewMask() *Mask {
+	return &Mask{
+		formatters: defaultMaskFormatters,
+	}
+}
+
+// Format masks string or every element of slice of strings.
+func (m *Mask) Format(tag reflect.StructTag, ptr any) error {
+	tagValue, ok := tag.Lookup(TagMask)
+	if !ok {
+		return nil
+	}
+
+	name := strings.TrimSpace(tagValue)
+
+	f, ok := m.formatters[name]
+	if !ok {
+		return errors.WithStack(rerr.FormatterNotFound{Tag: TagMask, Formatter: name})
+	}
+
+	switch v := ptr.(type) {
+	case *string:
+		*v = mask(f, *v)
+	case *[]string:
+		for i, s := range *v {
+			(*v)[i] = mask(f, s)
+		}
+	default:
+		return errors.Wrapf(rerr.NotSupported, "%T", ptr)
+	}
+
+	return nil
+}
+
+// Tag returns working tag.
+func (m *Mask) Tag() string {
+	return TagMask
+}
+
+// mask applies mask formatter to a non-empty string.
+func mask(f MaskFormatterFunc, str string) string {
+	if len(str) == 0 {
+		return str
+	}
+
+	return f(str)
+}
+
+// maskPartial keeps the first and the last runes of string replacing others with `*`,
+// e.g. `secret` to `s****t`, strings shorter than maskPartialMinLength are masked fully.
+func maskPartial(str string) string {
+	n := utf8.RuneCountInString(str)
+	if n < maskPartialMinLength {
+		return maskFullValue
+	}
+
+	first, _ := utf8.DecodeRuneInString(str)
+	last, _ := utf8.DecodeLastRuneInString(str)
+
+	return string(first) + strings.Repeat(maskChar, n-2) + string(last)
+}
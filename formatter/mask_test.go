@@ -0,0 +1,125 @@
+package formatter
+
+import (
+	"reflect"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMask(t *testing.T) {
+	m := NewMask()
+	require.NotNil(t, m)
+	require.Equal(t, TagMask, m.Tag())
+}
+
+func TestMask_Format(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     reflect.StructTag
+		value   any
+		want    any
+		wantErr error
+	}{
+		{
+			name:  "partial",
+			tag:   `mask:"partial"`,
+			value: "secret",
+			want:  "s****t",
+		},
+		{
+			name:  "partial of min length",
+			tag:   `mask:"partial"`,
+			value: "abcde",
+			want:  "a***e",
+		},
+		{
+			name:  "partial of short string",
+			tag:   `mask:"partial"`,
+			value: "abcd",
+			want:  "***",
+		},
+		{
+			name:  "partial of single char",
+			tag:   `mask:"partial"`,
+			value: "a",
+			want:  "***",
+		},
+		{
+			name:  "partial of multibyte string",
+			tag:   `mask:"partial"`,
+			value: "пароль",
+			want:  "п****ь",
+		},
+		{
+			name:  "full",
+			tag:   `mask:"full"`,
+			value: "4111111111111111",
+			want:  "***",
+		},
+		{
+			name:  "full of short string",
+			tag:   `mask:"full"`,
+			value: "a",
+			want:  "***",
+		},
+		{
+			name:  "sha256",
+			tag:   `mask:"sha256"`,
+			value: "secret",
+			want:  "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+		},
+		{
+			name:  "sha256 of short string",
+			tag:   `mask:"sha256"`,
+			value: "a",
+			want:  "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb",
+		},
+		{
+			name:  "empty string",
+			tag:   `mask:"full"`,
+			value: "",
+			want:  "",
+		},
+		{
+			name:  "slice",
+			tag:   `mask:"partial"`,
+			value: []string{"first-token", "", "abc"},
+			want:  []string{"f*********n", "", "***"},
+		},
+		{
+			name:    "unknown mask",
+			tag:     `mask:"half"`,
+			value:   "secret",
+			wantErr: rerr.FormatterNotFound{Tag: TagMask, Formatter: "half"},
+		},
+		{
+			name:    "not string",
+			tag:     `mask:"full"`,
+			value:   42,
+			wantErr: rerr.NotSupported,
+		},
+		{
+			name:  "without tag",
+			tag:   `json:"password"`,
+			value: "secret",
+			want:  "secret",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ptr := reflect.New(reflect.TypeOf(tt.value))
+			ptr.Elem().Set(reflect.ValueOf(tt.value))
+
+			err := NewMask().Format(tt.tag, ptr.Interface())
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, ptr.Elem().Interface())
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package formatter
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/value"
+)
+
+const (
+	// TagNumeric numeric tag.
+	TagNumeric = "numeric"
+	// NumericAbs numeric formatter taking absolute value, e.g. `numeric:"abs"`.
+	NumericAbs = "abs"
+	// NumericNonNeg numeric formatter clamping negative value to zero, e.g. `numeric:"nonneg"`.
+	NumericNonNeg = "nonneg"
+	// NumericMin numeric formatter clamping value to lower bound, e.g. `numeric:"min=1"`.
+	NumericMin = "min"
+	// NumericMax numeric formatter clamping value to upper bound, e.g. `numeric:"max=100"`.
+	NumericMax = "max"
+	// NumericRelaxed numeric formatter accepting grouping separators `_` and `,` in numbers, e.g. `numeric:"relaxed"`.
+	NumericRelaxed = "relaxed"
+)
+
+// Numeric is a numeric formatter of integer and float fields.
+//
+// Formatters are applied in order, e.g. `numeric:"abs,max=100"`, `abs` and `nonneg` don't change unsigned fields.
+// Grouping separators of strings of fields with `relaxed` formatter are stripped before conversion,
+// e.g. `1_000` or `1,000,000`, comma is assumed to be a grouping separator only, see value.RelaxNumber.
+type Numeric struct{}
+
+// NewNumeric returns new numeric formatter.
+func NewNumeric() *Numeric {
+	return &Numeric{}
+}
+
+// Format formats number.
+func (n *Numeric) Format(tag reflect.StructTag, ptr any) error {
+	tagValue, ok := tag.Lookup(TagNumeric)
+	if !ok {
+		return nil
+	}
+
+	v := reflect.ValueOf(ptr)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if !v.CanSet() {
+		return errors.Wrapf(rerr.NotSupported, "%T", ptr)
+	}
+
+	for _, name := range strings.Split(tagValue, ",") {
+		name = strings.TrimSpace(name)
+		if name == NumericRelaxed {
+			continue
+		}
+
+		if err := formatNumeric(v, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Tag returns working tag.
+func (n *Numeric) Tag() string {
+	return TagNumeric
+}
+
+// formatNumeric applies numeric formatter to a value.
+func formatNumeric(v reflect.Value, name string) error {
+	name, arg, _ := strings.Cut(name, "=")
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return formatInt(v, name, arg)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return formatUint(v, name, arg)
+	case reflect.Float32, reflect.Float64:
+		return formatFloat(v, name, arg)
+	default:
+		return errors.Wrapf(rerr.NotSupported, "%s", v.Type())
+	}
+}
+
+// formatInt applies numeric formatter to a signed integer.
+func formatInt(v reflect.Value, name, arg string) error {
+	i := v.Int()
+
+	switch name {
+	case NumericAbs:
+		if i >= 0 {
+			return nil
+		}
+
+		if i == math.MinInt64 || v.OverflowInt(-i) {
+			return errors.Wrapf(rerr.OutOfRange, "absolute value of %d", i)
+		}
+
+		v.SetInt(-i)
+	case NumericNonNeg:
+		if i < 0 {
+			v.SetInt(0)
+		}
+	case NumericMin, NumericMax:
+		bound, err := strconv.ParseInt(arg, 10, v.Type().Bits())
+		if err != nil {
+			return errors.Wrapf(rerr.InvalidTag, "%s:%q", TagNumeric, name+"="+arg)
+		}
+
+		if (name == NumericMin && i < bound) || (name == NumericMax && i > bound) {
+			v.SetInt(bound)
+		}
+	default:
+		return errors.WithStack(rerr.FormatterNotFound{Tag: TagNumeric, Formatter: name})
+	}
+
+	return nil
+}
+
+// formatUint applies numeric formatter to an unsigned integer.
+func formatUint(v reflect.Value, name, arg string) error {
+	switch name {
+	case NumericAbs, NumericNonNeg:
+	case NumericMin, NumericMax:
+		bound, err := strconv.ParseUint(arg, 10, v.Type().Bits())
+		if err != nil {
+			return errors.Wrapf(rerr.InvalidTag, "%s:%q", TagNumeric, name+"="+arg)
+		}
+
+		u := v.Uint()
+		if (name == NumericMin && u < bound) || (name == NumericMax && u > bound) {
+			v.SetUint(bound)
+		}
+	default:
+		return errors.WithStack(rerr.FormatterNotFound{Tag: TagNumeric, Formatter: name})
+	}
+
+	return nil
+}
+
+// formatFloat applies numeric formatter to a float.
+func formatFloat(v reflect.Value, name, arg string) error {
+	f := v.Float()
+
+	switch name {
+	case NumericAbs:
+		v.SetFloat(math.Abs(f))
+	case NumericNonNeg:
+		if f < 0 {
+			v.SetFloat(0)
+		}
+	case NumericMin, NumericMax:
+		bound, err := strconv.ParseFloat(arg, v.Type().Bits())
+		if err != nil {
+			return errors.Wrapf(rerr.InvalidTag, "%s:%q", TagNumeric, name+"="+arg)
+		}
+
+		if (name == NumericMin && f < bound) || (name == NumericMax && f > bound) {
+			v.SetFloat(bound)
+		}
+	default:
+		return errors.WithStack(rerr.FormatterNotFound{Tag: TagNumeric, Formatter: name})
+	}
+
+	return nil
+}
+
+// ParseString strips grouping separators of string of field with `relaxed` formatter, see value.RelaxNumber.
+func (n *Numeric) ParseString(tag reflect.StructTag, str string, _ time.Time) (any, bool, error) {
+	tagValue, ok := tag.Lookup(TagNumeric)
+	if !ok {
+		return nil, false, nil
+	}
+
+	for _, name := range strings.Split(tagValue, ",") {
+		if strings.TrimSpace(name) != NumericRelaxed {
+			continue
+		}
+
+		relaxed, err := value.RelaxNumber(strings.TrimSpace(str))
+		if err != nil {
+			return nil, false, err
+		}
+
+		return relaxed, true, nil
+	}
+
+	return nil, false, nil
+}
@@ -0,0 +1,240 @@
+package formatter
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNumeric(t *testing.T) {
+	f := NewNumeric()
+	require.NotNil(t, f)
+	require.Equal(t, TagNumeric, f.Tag())
+}
+
+func TestNumeric_Format(t *testing.T) {
+	negative := -7
+	negativeFloat := -2.5
+
+	tests := []struct {
+		name    string
+		tag     reflect.StructTag
+		ptr     any
+		want    any
+		wantErr error
+	}{
+		{
+			name: "abs of int",
+			tag:  `numeric:"abs"`,
+			ptr:  ptrTo(-5),
+			want: 5,
+		},
+		{
+			name: "abs of int8",
+			tag:  `numeric:"abs"`,
+			ptr:  ptrTo(int8(-127)),
+			want: int8(127),
+		},
+		{
+			name:    "abs of min int8",
+			tag:     `numeric:"abs"`,
+			ptr:     ptrTo(int8(math.MinInt8)),
+			wantErr: rerr.OutOfRange,
+		},
+		{
+			name:    "abs of min int64",
+			tag:     `numeric:"abs"`,
+			ptr:     ptrTo(int64(math.MinInt64)),
+			wantErr: rerr.OutOfRange,
+		},
+		{
+			name: "abs of float",
+			tag:  `numeric:"abs"`,
+			ptr:  ptrTo(-1.5),
+			want: 1.5,
+		},
+		{
+			name: "nonneg of int",
+			tag:  `numeric:"nonneg"`,
+			ptr:  ptrTo(int32(-3)),
+			want: int32(0),
+		},
+		{
+			name: "nonneg of positive int",
+			tag:  `numeric:"nonneg"`,
+			ptr:  ptrTo(3),
+			want: 3,
+		},
+		{
+			name: "nonneg of float",
+			tag:  `numeric:"nonneg"`,
+			ptr:  ptrTo(float32(-0.5)),
+			want: float32(0),
+		},
+		{
+			name: "abs and nonneg of unsigned",
+			tag:  `numeric:"abs,nonneg"`,
+			ptr:  ptrTo(uint(42)),
+			want: uint(42),
+		},
+		{
+			name: "abs with max",
+			tag:  `numeric:"abs, max=100"`,
+			ptr:  ptrTo(-500),
+			want: 100,
+		},
+		{
+			name: "nonneg with min",
+			tag:  `numeric:"nonneg,min=1"`,
+			ptr:  ptrTo(int64(-500)),
+			want: int64(1),
+		},
+		{
+			name: "min and max of unsigned",
+			tag:  `numeric:"min=10,max=20"`,
+			ptr:  ptrTo(uint16(5)),
+			want: uint16(10),
+		},
+		{
+			name: "max of float",
+			tag:  `numeric:"max=0.5"`,
+			ptr:  ptrTo(0.75),
+			want: 0.5,
+		},
+		{
+			name: "pointer field",
+			tag:  `numeric:"abs"`,
+			ptr:  ptrTo(&negative),
+			want: &negative,
+		},
+		{
+			name: "nil pointer field",
+			tag:  `numeric:"abs"`,
+			ptr:  ptrTo[*float64](nil),
+			want: (*float64)(nil),
+		},
+		{
+			name:    "bound out of range",
+			tag:     `numeric:"max=300"`,
+			ptr:     ptrTo(int8(1)),
+			wantErr: rerr.InvalidTag,
+		},
+		{
+			name:    "negative bound of unsigned",
+			tag:     `numeric:"min=-1"`,
+			ptr:     ptrTo(uint(1)),
+			wantErr: rerr.InvalidTag,
+		},
+		{
+			name: "relaxed of int",
+			tag:  `numeric:"relaxed,max=100"`,
+			ptr:  ptrTo(1000),
+			want: 100,
+		},
+		{
+			name: "relaxed of uint",
+			tag:  `numeric:"relaxed"`,
+			ptr:  ptrTo(uint(1000)),
+			want: uint(1000),
+		},
+		{
+			name: "relaxed of float",
+			tag:  `numeric:"relaxed"`,
+			ptr:  ptrTo(1000.5),
+			want: 1000.5,
+		},
+		{
+			name:    "unknown formatter",
+			tag:     `numeric:"round"`,
+			ptr:     ptrTo(1.5),
+			wantErr: rerr.FormatterNotFound{Tag: TagNumeric, Formatter: "round"},
+		},
+		{
+			name:    "not numeric",
+			tag:     `numeric:"abs"`,
+			ptr:     ptrTo("-1"),
+			wantErr: rerr.NotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewNumeric().Format(tt.tag, tt.ptr)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, reflect.ValueOf(tt.ptr).Elem().Interface())
+		})
+	}
+
+	require.Equal(t, 7, negative, "value of pointer field is formatted")
+
+	ptr := &negativeFloat
+	require.NoError(t, NewNumeric().Format(`json:"value"`, &ptr))
+	require.Equal(t, -2.5, negativeFloat, "field without tag is untouched")
+}
+
+func TestNumeric_ParseString(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     reflect.StructTag
+		str     string
+		want    any
+		handled bool
+		wantErr error
+	}{
+		{
+			name:    "relaxed",
+			tag:     `numeric:"relaxed"`,
+			str:     " 1_000 ",
+			want:    "1000",
+			handled: true,
+		},
+		{
+			name:    "relaxed with other formatters",
+			tag:     `numeric:"abs, relaxed"`,
+			str:     "1,000,000.25",
+			want:    "1000000.25",
+			handled: true,
+		},
+		{
+			name:    "malformed grouping",
+			tag:     `numeric:"relaxed"`,
+			str:     "1,00,0",
+			wantErr: rerr.InvalidFormat,
+		},
+		{
+			name: "not relaxed",
+			tag:  `numeric:"abs"`,
+			str:  "1_000",
+		},
+		{
+			name: "no tag",
+			tag:  `query:"limit"`,
+			str:  "1_000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, handled, err := NewNumeric().ParseString(tt.tag, tt.str, time.Time{})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.handled, handled)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
@@ -18,3 +18,13 @@ func WithExtendedStringFormatters(formatters StringsFormatters) StringOptionsFun
 		}
 	}
 }
+
+// MappingOptionsFunc function for setting mapping options.
+type MappingOptionsFunc = func(*Mapping)
+
+// WithStrictMapping enables error on values missing in mapping table.
+func WithStrictMapping() MappingOptionsFunc {
+	return func(m *Mapping) {
+		m.strict = true
+	}
+}
@@ -2,14 +2,54 @@ package formatter
 
 import (
 	"reflect"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 	rerr "github.com/slipros/roamer/err"
 )
 
 var defaultStringFormatters = StringsFormatters{
-	"trim_space": strings.TrimSpace,
+	"trim_space":     strings.TrimSpace,
+	"lower":          strings.ToLower,
+	"upper":          strings.ToUpper,
+	"enum_normalize": enumNormalize,
+}
+
+// stringArgFormatters formatters with argument passed after `=`, e.g. `string:"trim=/-"`.
+var stringArgFormatters = map[string]func(str, arg string) string{
+	"trim":       strings.Trim,
+	"trim_left":  strings.TrimLeft,
+	"trim_right": strings.TrimRight,
+}
+
+// stringLenLimits limits of string length with argument passed after `=`, e.g. `string:"maxlen=100"`.
+//
+// Length is measured in runes, `bytes` after the limit measures it in bytes, e.g. `string:"maxlen=100,bytes"`.
+var stringLenLimits = map[string]func(length, limit int) error{
+	"maxlen": func(length, limit int) error {
+		if length > limit {
+			return errors.Wrapf(rerr.InvalidFormat, "length %d is greater than %d", length, limit)
+		}
+
+		return nil
+	},
+	"minlen": func(length, limit int) error {
+		if length < limit {
+			return errors.Wrapf(rerr.InvalidFormat, "length %d is less than %d", length, limit)
+		}
+
+		return nil
+	},
+}
+
+// stringLenBytes modifier of string length limit to measure length in bytes.
+const stringLenBytes = "bytes"
+
+// enumNormalize returns canonical enum token: lower case words joined by underscores, e.g. `In Progress` to `in_progress`.
+func enumNormalize(str string) string {
+	return strings.Join(strings.Fields(strings.ToLower(str)), "_")
 }
 
 // StringFormatterFunc string formatter func.
@@ -42,27 +82,41 @@ func NewString(opts ...StringOptionsFunc) *String {
 }
 
 // Format format string.
+//
+// Every element of slice of strings is formatted, e.g. `query:"tags" string:"lower"`.
 func (s *String) Format(tag reflect.StructTag, ptr any) error {
 	tagValue, ok := tag.Lookup(TagString)
 	if !ok {
 		return nil
 	}
 
-	strPtr, ok := ptr.(*string)
-	if !ok {
+	switch v := ptr.(type) {
+	case *string:
+		return s.format(tagValue, v)
+	case *[]string:
+		for i := range *v {
+			if err := s.format(tagValue, &(*v)[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
 		return errors.Wrapf(rerr.NotSupported, "%T", ptr)
 	}
+}
 
+// format applies formatters of tag value to a string.
+func (s *String) format(tagValue string, strPtr *string) error {
 	if strings.Contains(tagValue, ",") {
 		str := *strPtr
-		for _, tagValue := range strings.Split(tagValue, ",") {
-			name := strings.TrimSpace(tagValue)
-			formatter, ok := s.formatters[name]
-			if !ok {
-				return errors.WithStack(rerr.FormatterNotFound{Tag: TagString, Formatter: name})
+		for _, tagValue := range splitStringTag(tagValue) {
+			formatted, err := s.apply(tagValue, str)
+			if err != nil {
+				return err
 			}
 
-			str = formatter(str)
+			str = formatted
 		}
 
 		*strPtr = str
@@ -70,16 +124,71 @@ func (s *String) Format(tag reflect.StructTag, ptr any) error {
 		return nil
 	}
 
-	formatter, ok := s.formatters[tagValue]
-	if !ok {
-		return errors.WithStack(rerr.FormatterNotFound{Tag: TagString, Formatter: tagValue})
+	formatted, err := s.apply(tagValue, *strPtr)
+	if err != nil {
+		return err
 	}
 
-	*strPtr = formatter(*strPtr)
+	*strPtr = formatted
 
 	return nil
 }
 
+// apply applies formatter to a string.
+//
+// Formatter with argument is declared as `name=arg`, e.g. `trim=/-`, the argument can't contain commas.
+func (s *String) apply(name, str string) (string, error) {
+	if formatter, ok := s.formatters[name]; ok {
+		return formatter(str), nil
+	}
+
+	if name, arg, found := strings.Cut(name, "="); found {
+		if formatter, ok := stringArgFormatters[name]; ok {
+			return formatter(str, arg), nil
+		}
+
+		if check, ok := stringLenLimits[name]; ok {
+			return str, checkStringLen(str, name, arg, check)
+		}
+	}
+
+	return "", errors.WithStack(rerr.FormatterNotFound{Tag: TagString, Formatter: name})
+}
+
+// checkStringLen checks length of a string against limit declared as `100` or `100,bytes`.
+func checkStringLen(str, name, arg string, check func(length, limit int) error) error {
+	arg, unit, _ := strings.Cut(arg, ",")
+	limit, err := strconv.Atoi(arg)
+	if err != nil || limit < 0 {
+		return errors.Wrapf(rerr.InvalidTag, "%s:%q", TagString, name+"="+arg)
+	}
+
+	length := utf8.RuneCountInString(str)
+	if unit == stringLenBytes {
+		length = len(str)
+	}
+
+	return check(length, limit)
+}
+
+// splitStringTag splits value of string tag into formatters, `bytes` is kept with preceding length limit.
+func splitStringTag(tagValue string) []string {
+	parts := strings.Split(tagValue, ",")
+	names := make([]string, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == stringLenBytes && len(names) > 0 {
+			names[len(names)-1] += "," + part
+			continue
+		}
+
+		names = append(names, part)
+	}
+
+	return names
+}
+
 // Tag returns working tag.
 func (s *String) Tag() string {
 	return TagString
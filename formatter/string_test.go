@@ -0,0 +1,198 @@
+package formatter
+
+import (
+	"reflect"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString_Format(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     reflect.StructTag
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "trim space",
+			tag:   `string:"trim_space"`,
+			value: "  test  ",
+			want:  "test",
+		},
+		{
+			name:  "trim punctuation",
+			tag:   `string:"trim=/-"`,
+			value: "/-path/segment-/",
+			want:  "path/segment",
+		},
+		{
+			name:  "trim left",
+			tag:   `string:"trim_left=/"`,
+			value: "//path/",
+			want:  "path/",
+		},
+		{
+			name:  "trim right",
+			tag:   `string:"trim_right=!?"`,
+			value: "what?!",
+			want:  "what",
+		},
+		{
+			name:  "trim combined with lower",
+			tag:   `string:"trim=*,lower"`,
+			value: "**TeSt**",
+			want:  "test",
+		},
+		{
+			name:  "trim space combined with trim and upper",
+			tag:   `string:"trim_space, trim=., upper"`,
+			value: " .test. ",
+			want:  "TEST",
+		},
+		{
+			name:  "enum normalize",
+			tag:   `string:"enum_normalize"`,
+			value: "In Progress",
+			want:  "in_progress",
+		},
+		{
+			name:  "enum normalize of surrounding and repeated spaces",
+			tag:   `string:"enum_normalize"`,
+			value: "  ON   Hold ",
+			want:  "on_hold",
+		},
+		{
+			name:    "unknown formatter",
+			tag:     `string:"unknown"`,
+			value:   "test",
+			wantErr: true,
+		},
+		{
+			name:    "unknown formatter with argument",
+			tag:     `string:"unknown=/"`,
+			value:   "test",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			str := tt.value
+			err := NewString().Format(tt.tag, &str)
+			if tt.wantErr {
+				var notFound rerr.FormatterNotFound
+				require.ErrorAs(t, err, &notFound)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, str)
+		})
+	}
+}
+
+func TestString_Format_Len(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     reflect.StructTag
+		value   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "maxlen of multibyte string at limit",
+			tag:   `string:"maxlen=5"`,
+			value: "привет"[:10],
+			want:  "приве",
+		},
+		{
+			name:    "maxlen of multibyte string beyond limit",
+			tag:     `string:"maxlen=5"`,
+			value:   "привет",
+			wantErr: rerr.InvalidFormat,
+		},
+		{
+			name:    "maxlen in bytes",
+			tag:     `string:"maxlen=10,bytes"`,
+			value:   "привет",
+			wantErr: rerr.InvalidFormat,
+		},
+		{
+			name:  "maxlen in bytes at limit",
+			tag:   `string:"maxlen=12,bytes"`,
+			value: "привет",
+			want:  "привет",
+		},
+		{
+			name:  "minlen of multibyte string at limit",
+			tag:   `string:"minlen=3"`,
+			value: "日本語",
+			want:  "日本語",
+		},
+		{
+			name:    "minlen of multibyte string below limit",
+			tag:     `string:"minlen=4"`,
+			value:   "日本語",
+			wantErr: rerr.InvalidFormat,
+		},
+		{
+			name:  "minlen in bytes",
+			tag:   `string:"minlen=9, bytes"`,
+			value: "日本語",
+			want:  "日本語",
+		},
+		{
+			name:  "limits after trim space",
+			tag:   `string:"trim_space,minlen=2,maxlen=3,bytes,upper"`,
+			value: "  ab  ",
+			want:  "AB",
+		},
+		{
+			name:    "invalid limit",
+			tag:     `string:"maxlen=ten"`,
+			value:   "test",
+			wantErr: rerr.InvalidTag,
+		},
+		{
+			name:    "invalid unit",
+			tag:     `string:"maxlen=10,bits"`,
+			value:   "test",
+			wantErr: rerr.FormatterNotFound{Tag: TagString, Formatter: "bits"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			str := tt.value
+			err := NewString().Format(tt.tag, &str)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, str)
+		})
+	}
+}
+
+func TestString_Format_Slice(t *testing.T) {
+	tags := []string{"Go", " Rust ", "python"}
+	err := NewString().Format(`string:"trim_space,lower"`, &tags)
+	require.NoError(t, err)
+	require.Equal(t, []string{"go", "rust", "python"}, tags)
+
+	var empty []string
+	err = NewString().Format(`string:"lower"`, &empty)
+	require.NoError(t, err)
+	require.Nil(t, empty)
+
+	tags = []string{"go", "ok"}
+	err = NewString().Format(`string:"minlen=3"`, &tags)
+	require.ErrorIs(t, err, rerr.InvalidFormat)
+
+	ids := []int{1}
+	err = NewString().Format(`string:"lower"`, &ids)
+	require.ErrorIs(t, err, rerr.NotSupported)
+}
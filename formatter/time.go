@@ -0,0 +1,212 @@
+package formatter
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/value"
+)
+
+const (
+	// TagTime time tag.
+	TagTime = "time"
+	// TimeUnix time formatter interpreting numeric string as unix timestamp in seconds, e.g. `time:"unix"`.
+	TimeUnix = "unix"
+	// TimeUnixMilli time formatter interpreting numeric string as unix timestamp in milliseconds, e.g. `time:"unix_ms"`.
+	TimeUnixMilli = "unix_ms"
+	// TimeRelative time formatter interpreting duration string as time relative to anchor, e.g. `time:"relative"`.
+	TimeRelative = "relative"
+	// TimeStartOfDay time formatter snapping time to start of its day, e.g. `time:"start_of_day"`.
+	TimeStartOfDay = "start_of_day"
+	// TimeEndOfDay time formatter snapping time to last nanosecond of its day, e.g. `time:"end_of_day"`.
+	TimeEndOfDay = "end_of_day"
+	// TimeStartOfMonth time formatter snapping time to start of its month, e.g. `time:"start_of_month"`.
+	TimeStartOfMonth = "start_of_month"
+	// TimeEndOfMonth time formatter snapping time to last nanosecond of its month, e.g. `time:"end_of_month"`.
+	TimeEndOfMonth = "end_of_month"
+	// TimeRetryAfter time formatter interpreting string as value of Retry-After header,
+	// delay in seconds relative to anchor or HTTP-date, e.g. `time:"retry_after"`.
+	TimeRetryAfter = "retry_after"
+	// TimeTimezone time formatter converting time into IANA time zone, e.g. `time:"timezone=America/New_York"`.
+	TimeTimezone = "timezone"
+)
+
+// locations cache of loaded time zones.
+var locations sync.Map
+
+// TimeFormatterFunc time formatter func.
+type TimeFormatterFunc = func(time.Time) time.Time
+
+var defaultTimeFormatters = map[string]TimeFormatterFunc{
+	TimeUnix: func(t time.Time) time.Time {
+		return t.Truncate(time.Second)
+	},
+	TimeUnixMilli: func(t time.Time) time.Time {
+		return t.Truncate(time.Millisecond)
+	},
+	TimeStartOfDay: func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	},
+	TimeEndOfDay: func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+	},
+	TimeStartOfMonth: func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	},
+	TimeEndOfMonth: func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+	},
+}
+
+// timeStringParsers names of time formatters which only parse string values, see Time.ParseString.
+var timeStringParsers = map[string]struct{}{
+	TimeRelative:   {},
+	TimeRetryAfter: {},
+}
+
+// Time is a time formatter.
+//
+// Numeric strings of fields with `unix` or `unix_ms` formatter are parsed as unix timestamps of the unit
+// regardless of amount of digits, the formatter truncates time of any source to precision of the unit.
+// Duration strings of fields with `relative` formatter are added to anchor time, e.g. `-2h`,
+// other strings are parsed as usual.
+// Strings of fields with `retry_after` formatter are parsed as Retry-After header value,
+// delay in seconds is added to anchor time, HTTP-date is set as is.
+// Time of field with `timezone=name` formatter is converted into the zone loaded by time.LoadLocation.
+// Boundary formatters, e.g. `end_of_month`, are computed in location of time,
+// `timezone=name` should precede them to snap time in another zone.
+type Time struct {
+	formatters map[string]TimeFormatterFunc
+}
+
+// NewTime returns new time formatter.
+func NewTime() *Time {
+	return &Time{
+		formatters: defaultTimeFormatters,
+	}
+}
+
+// Format formats time.
+func (t *Time) Format(tag reflect.StructTag, ptr any) error {
+	tagValue, ok := tag.Lookup(TagTime)
+	if !ok {
+		return nil
+	}
+
+	var timePtr *time.Time
+	switch v := ptr.(type) {
+	case *time.Time:
+		timePtr = v
+	case **time.Time:
+		if *v == nil {
+			return nil
+		}
+
+		timePtr = *v
+	default:
+		return errors.Wrapf(rerr.NotSupported, "%T", ptr)
+	}
+
+	if timePtr.IsZero() {
+		return nil
+	}
+
+	formatted := *timePtr
+	for _, name := range strings.Split(tagValue, ",") {
+		name = strings.TrimSpace(name)
+
+		if zone, ok := strings.CutPrefix(name, TimeTimezone+"="); ok {
+			loc, err := loadLocation(zone)
+			if err != nil {
+				return err
+			}
+
+			formatted = formatted.In(loc)
+			continue
+		}
+
+		if _, ok := timeStringParsers[name]; ok {
+			continue
+		}
+
+		formatter, ok := t.formatters[name]
+		if !ok {
+			return errors.WithStack(rerr.FormatterNotFound{Tag: TagTime, Formatter: name})
+		}
+
+		formatted = formatter(formatted)
+	}
+
+	*timePtr = formatted
+
+	return nil
+}
+
+// Tag returns working tag.
+func (t *Time) Tag() string {
+	return TagTime
+}
+
+// loadLocation returns cached time zone by name.
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.Wrapf(rerr.InvalidTag, "%s:%q: %s", TagTime, TimeTimezone+"="+name, err)
+	}
+
+	locations.Store(name, loc)
+
+	return loc, nil
+}
+
+// ParseString parses numeric string of field with `unix` or `unix_ms` formatter as unix timestamp of the unit,
+// duration string of field with `relative` formatter is added to anchor,
+// string of field with `retry_after` formatter is parsed by value.ParseRetryAfter relative to anchor.
+func (t *Time) ParseString(tag reflect.StructTag, str string, anchor time.Time) (any, bool, error) {
+	tagValue, ok := tag.Lookup(TagTime)
+	if !ok {
+		return nil, false, nil
+	}
+
+	for _, name := range strings.Split(tagValue, ",") {
+		var unit value.UnixUnit
+		switch strings.TrimSpace(name) {
+		case TimeUnix:
+			unit = value.UnixSeconds
+		case TimeUnixMilli:
+			unit = value.UnixMilli
+		case TimeRelative:
+			if d, err := time.ParseDuration(str); err == nil {
+				return anchor.Add(d), true, nil
+			}
+
+			continue
+		case TimeRetryAfter:
+			parsed, err := value.ParseRetryAfter(strings.TrimSpace(str), anchor)
+			if err != nil {
+				return nil, false, err
+			}
+
+			return parsed, true, nil
+		default:
+			continue
+		}
+
+		parsed, err := value.ParseUnix(str, unit)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return parsed, true, nil
+	}
+
+	return nil, false, nil
+}
@@ -0,0 +1,322 @@
+package formatter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTime(t *testing.T) {
+	f := NewTime()
+	require.NotNil(t, f)
+	require.Equal(t, TagTime, f.Tag())
+}
+
+func TestTime_Format(t *testing.T) {
+	ts := time.Date(2023, 11, 14, 22, 13, 20, 123456789, time.UTC)
+
+	tests := []struct {
+		name    string
+		tag     reflect.StructTag
+		value   time.Time
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name:  "unix",
+			tag:   `time:"unix"`,
+			value: ts,
+			want:  time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC),
+		},
+		{
+			name:  "unix_ms",
+			tag:   `time:"unix_ms"`,
+			value: ts,
+			want:  time.Date(2023, 11, 14, 22, 13, 20, 123000000, time.UTC),
+		},
+		{
+			name:  "zero time",
+			tag:   `time:"unix"`,
+			value: time.Time{},
+			want:  time.Time{},
+		},
+		{
+			name:    "unknown formatter",
+			tag:     `time:"unknown"`,
+			value:   ts,
+			wantErr: rerr.FormatterNotFound{Tag: TagTime, Formatter: "unknown"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.value
+			err := NewTime().Format(tt.tag, &v)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, v)
+
+			ptr := &tt.value
+			err = NewTime().Format(tt.tag, &ptr)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, *ptr)
+		})
+	}
+
+	var nilPtr *time.Time
+	require.NoError(t, NewTime().Format(`time:"unix"`, &nilPtr))
+	require.NoError(t, NewTime().Format(`json:"time"`, new(string)))
+	require.ErrorIs(t, NewTime().Format(`time:"unix"`, new(string)), rerr.NotSupported)
+}
+
+func TestTime_Format_Timezone(t *testing.T) {
+	tests := []struct {
+		name       string
+		tag        reflect.StructTag
+		value      time.Time
+		wantZone   string
+		wantOffset int
+		wantErr    error
+	}{
+		{
+			name:       "winter",
+			tag:        `time:"timezone=America/New_York"`,
+			value:      time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+			wantZone:   "America/New_York",
+			wantOffset: -5 * 3600,
+		},
+		{
+			name:       "summer",
+			tag:        `time:"timezone=America/New_York"`,
+			value:      time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC),
+			wantZone:   "America/New_York",
+			wantOffset: -4 * 3600,
+		},
+		{
+			name:       "with unix",
+			tag:        `time:"unix, timezone=Europe/Berlin"`,
+			value:      time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC),
+			wantZone:   "Europe/Berlin",
+			wantOffset: 2 * 3600,
+		},
+		{
+			name:       "utc",
+			tag:        `time:"timezone=UTC"`,
+			value:      time.Date(2024, 7, 15, 12, 0, 0, 0, time.FixedZone("MSK", 3*3600)),
+			wantZone:   "UTC",
+			wantOffset: 0,
+		},
+		{
+			name:    "unknown zone",
+			tag:     `time:"timezone=Mars/Olympus"`,
+			value:   time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC),
+			wantErr: rerr.InvalidTag,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.value
+			err := NewTime().Format(tt.tag, &v)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Equal(t, tt.value, v)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, tt.value.Equal(v))
+			require.Equal(t, tt.wantZone, v.Location().String())
+
+			_, offset := v.Zone()
+			require.Equal(t, tt.wantOffset, offset)
+		})
+	}
+}
+
+func TestTime_Format_Boundaries(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*3600)
+
+	tests := []struct {
+		name  string
+		tag   reflect.StructTag
+		value time.Time
+		want  time.Time
+	}{
+		{
+			name:  "start_of_day",
+			tag:   `time:"start_of_day"`,
+			value: time.Date(2024, 3, 10, 15, 4, 5, 6, time.UTC),
+			want:  time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "end_of_day",
+			tag:   `time:"end_of_day"`,
+			value: time.Date(2024, 3, 10, 15, 4, 5, 6, time.UTC),
+			want:  time.Date(2024, 3, 10, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "end_of_day in location",
+			tag:   `time:"end_of_day"`,
+			value: time.Date(2024, 12, 31, 1, 0, 0, 0, msk),
+			want:  time.Date(2024, 12, 31, 23, 59, 59, 999999999, msk),
+		},
+		{
+			name:  "start_of_month",
+			tag:   `time:"start_of_month"`,
+			value: time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC),
+			want:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "end_of_month 31 days",
+			tag:   `time:"end_of_month"`,
+			value: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, 1, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "end_of_month 30 days",
+			tag:   `time:"end_of_month"`,
+			value: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, 4, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "end_of_month december",
+			tag:   `time:"end_of_month"`,
+			value: time.Date(2024, 12, 5, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "end_of_month february leap year",
+			tag:   `time:"end_of_month"`,
+			value: time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, 2, 29, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "end_of_month february non-leap year",
+			tag:   `time:"end_of_month"`,
+			value: time.Date(2023, 2, 10, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2023, 2, 28, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "end_of_month february century non-leap year",
+			tag:   `time:"end_of_month"`,
+			value: time.Date(2100, 2, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2100, 2, 28, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "end_of_month february 400 years leap year",
+			tag:   `time:"end_of_month"`,
+			value: time.Date(2000, 2, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2000, 2, 29, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "end_of_month after timezone",
+			tag:   `time:"timezone=Europe/Moscow,end_of_month"`,
+			value: time.Date(2024, 4, 30, 22, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, 5, 31, 20, 59, 59, 999999999, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.value
+			err := NewTime().Format(tt.tag, &v)
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(v), "want %s, got %s", tt.want, v)
+		})
+	}
+}
+
+func TestTime_ParseString(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     reflect.StructTag
+		str     string
+		want    any
+		handled bool
+		wantErr error
+	}{
+		{
+			name:    "unix",
+			tag:     `time:"unix"`,
+			str:     "1700000000123",
+			want:    time.Unix(1700000000123, 0).UTC(),
+			handled: true,
+		},
+		{
+			name:    "unix_ms",
+			tag:     `time:"start_of_day, unix_ms"`,
+			str:     "1700000000",
+			want:    time.UnixMilli(1700000000).UTC(),
+			handled: true,
+		},
+		{
+			name:    "not numeric",
+			tag:     `time:"unix"`,
+			str:     "2023-11-14T22:13:20Z",
+			wantErr: rerr.NotSupported,
+		},
+		{
+			name:    "relative",
+			tag:     `time:"relative"`,
+			str:     "-2h",
+			want:    time.Date(2024, 3, 10, 10, 0, 0, 0, time.UTC),
+			handled: true,
+		},
+		{
+			name: "relative absolute time",
+			tag:  `time:"relative"`,
+			str:  "2024-03-10T10:00:00Z",
+		},
+		{
+			name:    "retry after seconds",
+			tag:     `time:"retry_after"`,
+			str:     " 120 ",
+			want:    time.Date(2024, 3, 10, 12, 2, 0, 0, time.UTC),
+			handled: true,
+		},
+		{
+			name:    "retry after date",
+			tag:     `time:"retry_after"`,
+			str:     "Wed, 21 Oct 2015 07:28:00 GMT",
+			want:    time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC),
+			handled: true,
+		},
+		{
+			name:    "too large retry after",
+			tag:     `time:"retry_after"`,
+			str:     "9999999999999",
+			wantErr: rerr.InvalidFormat,
+		},
+		{
+			name: "other formatter",
+			tag:  `time:"start_of_day"`,
+			str:  "1700000000",
+		},
+		{
+			name: "no tag",
+			tag:  `query:"time"`,
+			str:  "1700000000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			anchor := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+			got, handled, err := NewTime().ParseString(tt.tag, tt.str, anchor)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.handled, handled)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
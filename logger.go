@@ -0,0 +1,22 @@
+package roamer
+
+import (
+	"log/slog"
+	"reflect"
+)
+
+// Logger is a logger of parsing events, e.g. *slog.Logger.
+type Logger interface {
+	Debug(msg string, kv ...any)
+}
+
+var _ Logger = (*slog.Logger)(nil)
+
+// logNotParsed logs parsers which returned no value for a field.
+func (r *Roamer) logNotParsed(fieldType *reflect.StructField) {
+	for _, tag := range r.parserTags {
+		if _, ok := fieldType.Tag.Lookup(tag); ok {
+			r.logger.Debug("roamer: parser returned no value", "field", fieldType.Name, "tag", tag)
+		}
+	}
+}
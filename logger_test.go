@@ -0,0 +1,83 @@
+package roamer
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/slipros/roamer/decoder"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+type logEntry struct {
+	msg string
+	kv  []any
+}
+
+type capturingLogger struct {
+	entries []logEntry
+}
+
+func (c *capturingLogger) Debug(msg string, kv ...any) {
+	c.entries = append(c.entries, logEntry{msg: msg, kv: kv})
+}
+
+func TestWithLogger(t *testing.T) {
+	type Data struct {
+		Name    string `json:"name"`
+		Limit   int    `query:"limit"`
+		Agent   string `header:"User-Agent"`
+		Missing string `query:"missing"`
+	}
+
+	var logger capturingLogger
+
+	r := NewRoamer(
+		WithDecoders(decoder.NewJSON()),
+		WithParsers(parser.NewQuery(), parser.NewHeader()),
+		WithLogger(&logger),
+	)
+
+	req, err := http.NewRequest(http.MethodPost, "test.com?limit=ten", strings.NewReader(`{"name":"test"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.Error(t, err)
+
+	require.Equal(t, []logEntry{
+		{msg: "roamer: decoder selected", kv: []any{"content_type", decoder.ContentTypeJSON}},
+	}, logger.entries[:1])
+	require.Len(t, logger.entries, 2)
+	require.Equal(t, "roamer: set field value failed", logger.entries[1].msg)
+	require.Equal(t, []any{"field", "Limit", "tag", parser.TagQuery}, logger.entries[1].kv[:4])
+
+	logger.entries = nil
+
+	req, err = http.NewRequest(http.MethodPost, "test.com?limit=10", strings.NewReader(`{"name":"test"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "text/plain")
+
+	d = Data{}
+	err = r.Parse(req, &d)
+	require.ErrorIs(t, err, rerr.UnsupportedContentType)
+	require.Equal(t, []logEntry{
+		{msg: "roamer: decoder not found", kv: []any{"content_type", "text/plain"}},
+	}, logger.entries)
+
+	logger.entries = nil
+
+	req, err = http.NewRequest(http.MethodPost, "test.com?limit=10", nil)
+	require.NoError(t, err)
+
+	d = Data{}
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, []logEntry{
+		{msg: "roamer: parser returned no value", kv: []any{"field", "Agent", "tag", parser.TagHeader}},
+		{msg: "roamer: parser returned no value", kv: []any{"field", "Missing", "tag", parser.TagQuery}},
+	}, logger.entries)
+}
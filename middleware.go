@@ -3,6 +3,8 @@ package roamer
 import "net/http"
 
 // Middleware parse http request and saves the received value/error to context.
+//
+// Body preserved by WithPreserveBodyPool is released after next handler.
 func Middleware[T any](roamer *Roamer) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -11,6 +13,8 @@ func Middleware[T any](roamer *Roamer) func(next http.Handler) http.Handler {
 				return
 			}
 
+			defer ReleaseBody(r)
+
 			var v T
 			if err := roamer.Parse(r, &v); err != nil {
 				ctxWithError := ContextWithParsingError(r.Context(), err)
@@ -25,6 +29,8 @@ func Middleware[T any](roamer *Roamer) func(next http.Handler) http.Handler {
 }
 
 // SliceMiddleware parse http request and saves the received []value/error to context.
+//
+// Body preserved by WithPreserveBodyPool is released after next handler.
 func SliceMiddleware[T any](roamer *Roamer) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -33,6 +39,8 @@ func SliceMiddleware[T any](roamer *Roamer) func(next http.Handler) http.Handler
 				return
 			}
 
+			defer ReleaseBody(r)
+
 			var v []T
 			if err := roamer.Parse(r, &v); err != nil {
 				ctxWithError := ContextWithParsingError(r.Context(), err)
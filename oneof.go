@@ -0,0 +1,79 @@
+package roamer
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+const (
+	// TagOneOf oneof tag.
+	TagOneOf = "oneof"
+	// oneOfCaseInsensitive option of oneof tag enabling case-insensitive comparison.
+	oneOfCaseInsensitive = "ci"
+)
+
+// validateOneOf checks that field value is one of space separated values declared by oneof tag,
+// e.g. `oneof:"open closed"`, comparison is case-insensitive with `ci` option: `oneof:"open closed,ci"`.
+//
+// Empty values are not validated, every element of slice is validated.
+func validateOneOf(tag reflect.StructTag, fieldValue reflect.Value) error {
+	tagValue, ok := tag.Lookup(TagOneOf)
+	if !ok {
+		return nil
+	}
+
+	values, option, _ := strings.Cut(tagValue, ",")
+	allowed := strings.Fields(values)
+	caseInsensitive := strings.TrimSpace(option) == oneOfCaseInsensitive
+
+	if fieldValue.Kind() == reflect.Pointer {
+		if fieldValue.IsNil() {
+			return nil
+		}
+
+		fieldValue = fieldValue.Elem()
+	}
+
+	if fieldValue.Kind() == reflect.Slice && fieldValue.Type().Elem().Kind() != reflect.Uint8 {
+		for i := range fieldValue.Len() {
+			if err := checkOneOf(fieldValue.Index(i), allowed, caseInsensitive); err != nil {
+				return errors.WithStack(rerr.SliceIterationError{Err: err, Index: i})
+			}
+		}
+
+		return nil
+	}
+
+	return checkOneOf(fieldValue, allowed, caseInsensitive)
+}
+
+// checkOneOf checks that value is one of allowed values.
+func checkOneOf(v reflect.Value, allowed []string, caseInsensitive bool) error {
+	if v.IsZero() {
+		return nil
+	}
+
+	var str string
+	switch v.Kind() {
+	case reflect.String:
+		str = v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		str = strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		str = strconv.FormatUint(v.Uint(), 10)
+	default:
+		return errors.Wrapf(rerr.NotSupported, "oneof of `%s`", v.Type())
+	}
+
+	for _, a := range allowed {
+		if a == str || (caseInsensitive && strings.EqualFold(a, str)) {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(rerr.NotOneOf, "`%s` is not one of [%s]", str, strings.Join(allowed, ", "))
+}
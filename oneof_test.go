@@ -0,0 +1,145 @@
+package roamer
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOneOf(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		tag     reflect.StructTag
+		value   any
+		wantErr error
+	}{
+		{
+			name:  "no tag",
+			tag:   `query:"status"`,
+			value: "unknown",
+		},
+		{
+			name:  "valid",
+			tag:   `oneof:"open closed pending"`,
+			value: "closed",
+		},
+		{
+			name:    "invalid",
+			tag:     `oneof:"open closed pending"`,
+			value:   "archived",
+			wantErr: rerr.NotOneOf,
+		},
+		{
+			name:    "case sensitive by default",
+			tag:     `oneof:"open closed pending"`,
+			value:   "Open",
+			wantErr: rerr.NotOneOf,
+		},
+		{
+			name:  "case insensitive",
+			tag:   `oneof:"open closed pending,ci"`,
+			value: "OPEN",
+		},
+		{
+			name:  "empty value",
+			tag:   `oneof:"open closed"`,
+			value: "",
+		},
+		{
+			name:  "nil pointer",
+			tag:   `oneof:"open closed"`,
+			value: (*string)(nil),
+		},
+		{
+			name:    "pointer",
+			tag:     `oneof:"open closed"`,
+			value:   strPtr("archived"),
+			wantErr: rerr.NotOneOf,
+		},
+		{
+			name:  "integer",
+			tag:   `oneof:"10 20 50"`,
+			value: 20,
+		},
+		{
+			name:    "slice",
+			tag:     `oneof:"open closed"`,
+			value:   []string{"open", "archived"},
+			wantErr: rerr.NotOneOf,
+		},
+		{
+			name:    "not supported field",
+			tag:     `oneof:"open closed"`,
+			value:   1.5,
+			wantErr: rerr.NotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOneOf(tt.tag, reflect.ValueOf(tt.value))
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRoamer_Parse_OneOf(t *testing.T) {
+	type Data struct {
+		Status string `query:"status" oneof:"open closed pending"`
+		Sort   string `query:"sort" oneof:"asc desc,ci"`
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		want    Data
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			query: "status=open&sort=asc",
+			want:  Data{Status: "open", Sort: "asc"},
+		},
+		{
+			name:    "invalid",
+			query:   "status=archived",
+			wantErr: true,
+		},
+		{
+			name:  "case insensitive",
+			query: "sort=DESC",
+			want:  Data{Sort: "DESC"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?"+tt.query, nil)
+			require.NoError(t, err)
+
+			var d Data
+			err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &d)
+			if tt.wantErr {
+				require.ErrorIs(t, err, rerr.NotOneOf)
+				require.ErrorContains(t, err, "open, closed, pending")
+
+				parseErr, ok := IsParseError(err)
+				require.True(t, ok)
+				require.Equal(t, "Status", parseErr.Field)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
@@ -1,13 +1,26 @@
 package roamer
 
+import (
+	"sync"
+
+	"github.com/slipros/roamer/parser"
+)
+
 // OptionsFunc function for setting options.
 type OptionsFunc func(*Roamer)
 
 // WithParsers sets parsers.
+//
+// Parsers fill a field with several parser tags in order they were set, the first parser which returned a value wins.
 func WithParsers(parsers ...Parser) OptionsFunc {
 	return func(r *Roamer) {
 		for _, p := range parsers {
-			r.parsers[p.Tag()] = p
+			tag := p.Tag()
+			if _, ok := r.parsers[tag]; !ok {
+				r.parserTags = append(r.parserTags, tag)
+			}
+
+			r.parsers[tag] = p
 		}
 	}
 }
@@ -21,6 +34,15 @@ func WithDecoders(decoders ...Decoder) OptionsFunc {
 	}
 }
 
+// WithDefaultDecoder sets decoder used for body with missing or unmatched content type.
+//
+// Without default decoder body with content type unmatched by decoders results in rerr.UnsupportedContentType error.
+func WithDefaultDecoder(d Decoder) OptionsFunc {
+	return func(r *Roamer) {
+		r.defaultDecoder = d
+	}
+}
+
 // WithFormatters sets formatters.
 func WithFormatters(formatters ...Formatter) OptionsFunc {
 	return func(r *Roamer) {
@@ -37,6 +59,199 @@ func WithSkipFilled(skip bool) OptionsFunc {
 	}
 }
 
+// WithTreatBlankAsEmpty enables treating of parsed values consisting of whitespaces only as absent,
+// so the field is filled from other sources or by default tag and fails required tag.
+func WithTreatBlankAsEmpty() OptionsFunc {
+	return func(r *Roamer) {
+		r.treatBlankAsEmpty = true
+	}
+}
+
+// WithFieldNameMapper sets mapper deriving keys of request data from names of fields without tags,
+// e.g. `roamer.WithFieldNameMapper(roamer.SnakeCase)` binds field `UserID` from `user_id` key.
+func WithFieldNameMapper(mapper FieldNameMapper) OptionsFunc {
+	return func(r *Roamer) {
+		r.fieldNameMapper = mapper
+	}
+}
+
+// WithFieldNameMatcher sets matcher of request keys used by query and header parsers for keys missing in request,
+// e.g. `roamer.WithFieldNameMatcher(parser.LooseMatch)` binds `query:"user_id"` from `userId` or `USERID`.
+//
+// Exact key takes precedence, for several matched keys the first in sorted order is used.
+// Matcher is passed to parsers by cache with parser.CacheKeyFieldNameMatcher key.
+func WithFieldNameMatcher(matcher parser.FieldNameMatcher) OptionsFunc {
+	return func(r *Roamer) {
+		r.fieldNameMatcher = matcher
+	}
+}
+
+// WithAllowedFields allows filling only listed struct fields from request by decoders and parsers,
+// other fields are left untouched even if request has their values.
+// Default and validation tags, e.g. `required`, are still applied to fields which are not allowed.
+//
+// Names are compared with names of struct fields case-insensitively ignoring underscores and dashes,
+// e.g. `roamer.WithAllowedFields("name", "user_id")` allows fields `Name` and `UserID`.
+func WithAllowedFields(names ...string) OptionsFunc {
+	return func(r *Roamer) {
+		if r.allowedFields == nil {
+			r.allowedFields = make(map[string]struct{}, len(names))
+		}
+
+		for _, name := range names {
+			r.allowedFields[normalizeFieldName(name)] = struct{}{}
+		}
+	}
+}
+
+// WithProtectedFields forbids filling listed struct fields from request by decoders and parsers
+// regardless of tags, protection takes precedence over WithAllowedFields.
+//
+// Names are compared the same way as in WithAllowedFields,
+// e.g. `roamer.WithProtectedFields("is_admin", "id")` protects fields `IsAdmin` and `ID`.
+// Fields promoted from embedded structs are protected by their own names as well.
+// Default and validation tags, e.g. `required`, are still applied to protected fields.
+func WithProtectedFields(names ...string) OptionsFunc {
+	return func(r *Roamer) {
+		if r.protectedFields == nil {
+			r.protectedFields = make(map[string]struct{}, len(names))
+		}
+
+		for _, name := range names {
+			r.protectedFields[normalizeFieldName(name)] = struct{}{}
+		}
+	}
+}
+
+// WithRequestSizeLimit limits size of request body read by decoders to n bytes.
+//
+// Exceeding the limit fails parsing with rerr.RequestTooLarge error.
+func WithRequestSizeLimit(n int64) OptionsFunc {
+	return func(r *Roamer) {
+		r.requestSizeLimit = n
+	}
+}
+
+// WithPreserveBody buffers request body, so it can be read again after parsing.
+func WithPreserveBody() OptionsFunc {
+	return func(r *Roamer) {
+		r.preserveBody = true
+	}
+}
+
+// WithPreserveBodyPool buffers request body like WithPreserveBody into buffers of the pool,
+// so buffers are recycled across requests instead of allocating a new one for each body.
+//
+// Buffer is reset and returned to the pool when preserved body is closed or released by ReleaseBody,
+// Middleware and SliceMiddleware release it after handler, other handlers call ReleaseBody themselves.
+// Body must not be read after it is released. Pool can have New function returning *bytes.Buffer,
+// otherwise a new buffer is allocated for empty pool.
+func WithPreserveBodyPool(pool *sync.Pool) OptionsFunc {
+	return func(r *Roamer) {
+		r.preserveBody = true
+		r.preserveBodyPool = pool
+	}
+}
+
+// WithContentDecoding enables decompression of request body with `gzip` or `deflate` Content-Encoding
+// before decoders run.
+//
+// Request size limit applies to decompressed body. Preserved body is decompressed,
+// so Content-Encoding header is removed and Content-Length is updated.
+func WithContentDecoding() OptionsFunc {
+	return func(r *Roamer) {
+		r.contentDecoding = true
+	}
+}
+
+// WithCacheFactory sets function creating cache of parsers for each parsed request instead of allocating a new map,
+// e.g. taking maps from sync.Pool.
+//
+// Factory returning nil cache falls back to a new map.
+func WithCacheFactory(factory func() parser.Cache) OptionsFunc {
+	return func(r *Roamer) {
+		r.cacheFactory = factory
+	}
+}
+
+// WithCacheRelease sets function called with cache of parsers after a request is parsed,
+// e.g. clearing the map and returning it to sync.Pool.
+//
+// Cache must not be used after release by parsers, values of cache can still be referenced by parsed struct.
+func WithCacheRelease(release func(parser.Cache)) OptionsFunc {
+	return func(r *Roamer) {
+		r.cacheRelease = release
+	}
+}
+
+// WithDefaultFuncs adds default funcs called by name from default tag, e.g. `default:"$now"` for `now` func.
+func WithDefaultFuncs(funcs DefaultFuncs) OptionsFunc {
+	return func(r *Roamer) {
+		for name, fn := range funcs {
+			r.defaultFuncs[name] = fn
+		}
+	}
+}
+
+// WithValidator sets validator called with parsed value at the end of Parse,
+// its error is returned wrapped in rerr.ValidationError.
+func WithValidator(validator ValidatorFunc) OptionsFunc {
+	return func(r *Roamer) {
+		r.validator = validator
+	}
+}
+
+// WithRelativeTimeAnchor sets anchor of relative time values of `time:"relative"` fields
+// and delays of `time:"retry_after"` fields, e.g. roamer.AnchorDateHeader,
+// by default values are relative to current time. Anchor is passed to formatters implementing StringParser,
+// e.g. formatter.Time.
+func WithRelativeTimeAnchor(anchor RelativeTimeAnchor) OptionsFunc {
+	return func(r *Roamer) {
+		r.relativeTimeAnchor = anchor
+	}
+}
+
+// WithLogger sets logger of parsing events: selected decoders, fields without parsed values
+// and failed conversions.
+func WithLogger(logger Logger) OptionsFunc {
+	return func(r *Roamer) {
+		r.logger = logger
+	}
+}
+
+// WithStrictTags enables failing of parsing of struct with field tags unknown to roamer, its parsers, formatters
+// and decoders implementing TaggedDecoder, e.g. misspelled `quey:"id"`, with rerr.InvalidTag error listing them.
+//
+// Tags used by other libraries, e.g. `validate`, should be passed as allowed.
+// Only fields of parsed struct are checked, each struct type is checked once.
+func WithStrictTags(allowed ...string) OptionsFunc {
+	return func(r *Roamer) {
+		r.strictTags = true
+		r.strictAllowedTags = append(r.strictAllowedTags, allowed...)
+	}
+}
+
+// WithTypeResolver sets resolver of concrete type for polymorphic body,
+// it is called when destination is a pointer to an interface or before decoding of body into nil interface fields
+// with resolve tag, e.g. `json:"payload" resolve:"true"`.
+//
+// Resolver must return a pointer assignable to the interface, json decoders decode body into the value
+// it points to, without resolver json decoder fills empty interface with map[string]any
+// and fails for interfaces with methods.
+func WithTypeResolver(resolver TypeResolver) OptionsFunc {
+	return func(r *Roamer) {
+		r.typeResolver = resolver
+	}
+}
+
+// WithUsageTracking enables tracking of used decoders, parsers and formatters,
+// components which were never used are reported by Roamer.UnusedComponents, e.g. to check configuration in tests.
+func WithUsageTracking() OptionsFunc {
+	return func(r *Roamer) {
+		r.usageTracking = true
+	}
+}
+
 // WithExperimentalFastStructFieldParser enables the use of experimental fast struct field parser.
 func WithExperimentalFastStructFieldParser() OptionsFunc {
 	return func(r *Roamer) {
@@ -15,5 +15,31 @@ type Parser interface {
 	Tag() string
 }
 
+// DestinationParser is a parser which has access to destination struct while parsing,
+// the destination is filled by decoders and previous fields, e.g. to parse a value only if other field is empty.
+//
+// ParseWithDestination is called instead of Parse, destination must not be modified.
+type DestinationParser interface {
+	Parser
+	ParseWithDestination(r *http.Request, tag reflect.StructTag, cache parser.Cache, dst reflect.Value) (any, bool)
+}
+
+// ErrorParser is a parser which rejects invalid values with an error, returned as rerr.ParseError by roamer.
+//
+// ParseWithError is called instead of Parse.
+type ErrorParser interface {
+	Parser
+	ParseWithError(r *http.Request, tag reflect.StructTag, cache parser.Cache) (any, bool, error)
+}
+
+// ConsumingParser is a parser which tracks consumed values of a source, e.g. for `query:"*"` fields.
+//
+// Consume is called for fields whose value is not parsed by the parser, e.g. pre-filled, denied
+// or parsed by a previous parser, so values of such fields are not caught by catch-all fields.
+type ConsumingParser interface {
+	Parser
+	Consume(r *http.Request, tag reflect.StructTag, cache parser.Cache)
+}
+
 // Parsers is a map of parsers where keys are tags for given parsers.
 type Parsers map[string]Parser
@@ -0,0 +1,53 @@
+package parser
+
+// cacheKeyArtifactPrefix prefix of cache keys of shared artifacts.
+const cacheKeyArtifactPrefix = "artifact_"
+
+// ArtifactKey is a typed key of an artifact shared between parsers through cache within one request,
+// e.g. a decoded token used by several parsers.
+type ArtifactKey[T any] struct {
+	name string
+}
+
+// NewArtifactKey returns new artifact key.
+//
+// Name must be unique across all parsers sharing cache.
+func NewArtifactKey[T any](name string) ArtifactKey[T] {
+	return ArtifactKey[T]{name: cacheKeyArtifactPrefix + name}
+}
+
+// artifact is a cached result of artifact loading.
+type artifact[T any] struct {
+	value T
+	ok    bool
+}
+
+// LoadArtifact returns artifact stored in cache.
+func LoadArtifact[T any](cache Cache, key ArtifactKey[T]) (T, bool) {
+	a, ok := cache[key.name].(artifact[T])
+	if !ok {
+		var zero T
+		return zero, false
+	}
+
+	return a.value, a.ok
+}
+
+// StoreArtifact stores artifact in cache.
+func StoreArtifact[T any](cache Cache, key ArtifactKey[T], value T) {
+	cache[key.name] = artifact[T]{value: value, ok: true}
+}
+
+// LoadOrParseArtifact returns artifact stored in cache or parses and stores it.
+//
+// Parse is called at most once per cache, failed result is cached as well.
+func LoadOrParseArtifact[T any](cache Cache, key ArtifactKey[T], parse func() (T, bool)) (T, bool) {
+	if a, ok := cache[key.name].(artifact[T]); ok {
+		return a.value, a.ok
+	}
+
+	value, ok := parse()
+	cache[key.name] = artifact[T]{value: value, ok: ok}
+
+	return value, ok
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifact(t *testing.T) {
+	key := NewArtifactKey[[]string]("test")
+
+	t.Run("Load or parse once", func(t *testing.T) {
+		cache := make(Cache)
+
+		calls := 0
+		parse := func() ([]string, bool) {
+			calls++
+			return []string{"a"}, true
+		}
+
+		for range 3 {
+			v, ok := LoadOrParseArtifact(cache, key, parse)
+			require.True(t, ok)
+			require.Equal(t, []string{"a"}, v)
+		}
+
+		require.Equal(t, 1, calls)
+
+		v, ok := LoadArtifact(cache, key)
+		require.True(t, ok)
+		require.Equal(t, []string{"a"}, v)
+	})
+
+	t.Run("Failed parse is cached", func(t *testing.T) {
+		cache := make(Cache)
+
+		calls := 0
+		parse := func() ([]string, bool) {
+			calls++
+			return nil, false
+		}
+
+		_, ok := LoadOrParseArtifact(cache, key, parse)
+		require.False(t, ok)
+
+		_, ok = LoadOrParseArtifact(cache, key, parse)
+		require.False(t, ok)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("Store and load", func(t *testing.T) {
+		cache := make(Cache)
+
+		_, ok := LoadArtifact(cache, key)
+		require.False(t, ok)
+
+		StoreArtifact(cache, key, []string{"b"})
+
+		v, ok := LoadArtifact(cache, key)
+		require.True(t, ok)
+		require.Equal(t, []string{"b"}, v)
+	})
+
+	t.Run("Keys of different types do not collide", func(t *testing.T) {
+		cache := make(Cache)
+
+		StoreArtifact(cache, key, []string{"b"})
+
+		_, ok := LoadArtifact(cache, NewArtifactKey[string]("test"))
+		require.False(t, ok)
+	})
+}
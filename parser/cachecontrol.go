@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	// TagCacheControl cache control tag, value is a name of directive, e.g. `cachecontrol:"max-age"`.
+	TagCacheControl = "cachecontrol"
+	// HeaderCacheControl header of cache control directives.
+	HeaderCacheControl = "Cache-Control"
+	// CacheControlPresent value of directive without argument, e.g. `no-cache`.
+	CacheControlPresent  = "true"
+	cacheKeyCacheControl = "cache_control"
+)
+
+// CacheControl is a parser of Cache-Control header directives,
+// e.g. `no-cache, max-age=60` fills `cachecontrol:"no-cache"` bool field and `cachecontrol:"max-age"` int field.
+//
+// Directive without argument has value `true`, tag value `*` returns all directives as map[string]string.
+type CacheControl struct{}
+
+// NewCacheControl returns new cache control parser.
+func NewCacheControl() *CacheControl {
+	return &CacheControl{}
+}
+
+// Parse parses cache control directive from request.
+func (c *CacheControl) Parse(r *http.Request, tag reflect.StructTag, cache Cache) (any, bool) {
+	tagValue, ok := tag.Lookup(TagCacheControl)
+	if !ok {
+		return "", false
+	}
+
+	tagValue, _ = SplitTagValue(tagValue)
+
+	directives, ok := cache[cacheKeyCacheControl].(map[string]string)
+	if !ok {
+		directives = parseCacheControl(r.Header.Values(HeaderCacheControl))
+		cache[cacheKeyCacheControl] = directives
+	}
+
+	if len(directives) == 0 {
+		return "", false
+	}
+
+	if tagValue == TagValueAll {
+		return directives, true
+	}
+
+	directive, ok := directives[strings.ToLower(tagValue)]
+	return directive, ok
+}
+
+// Tag returns working tag.
+func (c *CacheControl) Tag() string {
+	return TagCacheControl
+}
+
+// parseCacheControl parses comma-separated directives with optional arguments, names are lowercase.
+func parseCacheControl(headers []string) map[string]string {
+	directives := make(map[string]string)
+	for _, header := range headers {
+		for _, directive := range strings.Split(header, ",") {
+			name, arg, found := strings.Cut(directive, "=")
+
+			name = strings.ToLower(strings.TrimSpace(name))
+			if len(name) == 0 {
+				continue
+			}
+
+			if !found {
+				directives[name] = CacheControlPresent
+				continue
+			}
+
+			directives[name] = strings.Trim(strings.TrimSpace(arg), `"`)
+		}
+	}
+
+	return directives
+}
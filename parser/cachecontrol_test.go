@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCacheControl(t *testing.T) {
+	c := NewCacheControl()
+	require.NotNil(t, c)
+	require.Equal(t, TagCacheControl, c.Tag())
+}
+
+func TestCacheControl(t *testing.T) {
+	tests := []struct {
+		name      string
+		headers   []string
+		tag       reflect.StructTag
+		want      any
+		notExists bool
+	}{
+		{
+			name:    "Directive without argument",
+			headers: []string{"no-cache, max-age=60"},
+			tag:     `cachecontrol:"no-cache"`,
+			want:    CacheControlPresent,
+		},
+		{
+			name:    "Directive with argument",
+			headers: []string{"no-cache, max-age=60"},
+			tag:     `cachecontrol:"max-age"`,
+			want:    "60",
+		},
+		{
+			name:    "Case insensitive directive with quoted argument",
+			headers: []string{`MAX-STALE="30"`},
+			tag:     `cachecontrol:"Max-Stale"`,
+			want:    "30",
+		},
+		{
+			name:    "Repeated headers",
+			headers: []string{"no-store", "no-transform, only-if-cached"},
+			tag:     `cachecontrol:"only-if-cached"`,
+			want:    CacheControlPresent,
+		},
+		{
+			name:    "All directives",
+			headers: []string{"no-cache,, max-age=0"},
+			tag:     `cachecontrol:"*"`,
+			want:    map[string]string{"no-cache": CacheControlPresent, "max-age": "0"},
+		},
+		{
+			name:      "Missing directive",
+			headers:   []string{"no-cache"},
+			tag:       `cachecontrol:"max-age"`,
+			notExists: true,
+		},
+		{
+			name:      "Missing header",
+			tag:       `cachecontrol:"no-cache"`,
+			notExists: true,
+		},
+		{
+			name:      "Missing tag",
+			headers:   []string{"no-cache"},
+			tag:       `header:"Cache-Control"`,
+			notExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+			require.NoError(t, err)
+
+			for _, h := range tt.headers {
+				req.Header.Add(HeaderCacheControl, h)
+			}
+
+			got, exists := NewCacheControl().Parse(req, tt.tag, make(Cache))
+			if tt.notExists {
+				require.False(t, exists)
+				return
+			}
+
+			require.True(t, exists)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -25,6 +25,8 @@ func (c *Cookie) Parse(r *http.Request, tag reflect.StructTag, _ Cache) (any, bo
 		return "", false
 	}
 
+	tagValue, _ = SplitTagValue(tagValue)
+
 	v, err := r.Cookie(tagValue)
 	if err != nil {
 		return "", false
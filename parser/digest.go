@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	// TagDigest digest tag, value is a name of digest auth parameter, e.g. `digest:"username"`.
+	TagDigest = "digest"
+	// HeaderAuthorization authorization header.
+	HeaderAuthorization = "Authorization"
+	// DigestScheme scheme of digest auth in authorization header.
+	DigestScheme   = "Digest"
+	cacheKeyDigest = "digest"
+)
+
+// Digest is a parser of digest auth parameters of Authorization header,
+// e.g. `Digest username="bob", realm="api", nonce="abc"` fills `digest:"username"` field with `bob`.
+//
+// Parameter names are case-insensitive, quoted values are unquoted and may contain commas,
+// tag value `*` returns all parameters as map[string]string.
+// Authorization header with another scheme, e.g. `Basic`, is ignored.
+type Digest struct{}
+
+// NewDigest returns new digest auth parser.
+func NewDigest() *Digest {
+	return &Digest{}
+}
+
+// Parse parses digest auth parameter from request.
+func (d *Digest) Parse(r *http.Request, tag reflect.StructTag, cache Cache) (any, bool) {
+	tagValue, ok := tag.Lookup(TagDigest)
+	if !ok {
+		return "", false
+	}
+
+	tagValue, _ = SplitTagValue(tagValue)
+
+	params, ok := cache[cacheKeyDigest].(map[string]string)
+	if !ok {
+		params = parseDigest(r.Header.Get(HeaderAuthorization))
+		cache[cacheKeyDigest] = params
+	}
+
+	if len(params) == 0 {
+		return "", false
+	}
+
+	if tagValue == TagValueAll {
+		return params, true
+	}
+
+	param, ok := params[strings.ToLower(tagValue)]
+	return param, ok
+}
+
+// Tag returns working tag.
+func (d *Digest) Tag() string {
+	return TagDigest
+}
+
+// parseDigest parses comma-separated parameters of digest authorization header, names are lowercase.
+func parseDigest(header string) map[string]string {
+	scheme, list, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found || !strings.EqualFold(scheme, DigestScheme) {
+		return nil
+	}
+
+	params := make(map[string]string)
+	for len(list) > 0 {
+		var name, value string
+
+		name, list, found = strings.Cut(list, "=")
+		name = strings.ToLower(strings.TrimSpace(strings.TrimLeft(name, ", ")))
+		if !found || len(name) == 0 {
+			break
+		}
+
+		value, list = digestValue(strings.TrimLeft(list, " "))
+		params[name] = value
+	}
+
+	return params
+}
+
+// digestValue returns token or unquoted quoted string value of parameter and the rest of list.
+func digestValue(list string) (string, string) {
+	if !strings.HasPrefix(list, `"`) {
+		value, rest, _ := strings.Cut(list, ",")
+		return strings.TrimSpace(value), rest
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(list); i++ {
+		switch list[i] {
+		case '\\':
+			if i+1 < len(list) {
+				i++
+				b.WriteByte(list[i])
+			}
+		case '"':
+			_, rest, _ := strings.Cut(list[i+1:], ",")
+			return b.String(), rest
+		default:
+			b.WriteByte(list[i])
+		}
+	}
+
+	// unterminated quoted string.
+	return b.String(), ""
+}
@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDigestHeader = `Digest username="Mufasa", realm="http-auth@example.org", ` +
+	`uri="/dir/index.html?a=1,2", algorithm=SHA-256, nonce="7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v", ` +
+	`nc=00000001, cnonce="f2/wE4q74E6zIJEtWaHKaf5wv/H5QzzpXusqGemxURZJ", qop=auth, ` +
+	`response="753927fa0e85d155564e2e272a28d1802ca10daf4496794697cf8db5856cb6c1", ` +
+	`opaque="FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"`
+
+func TestNewDigest(t *testing.T) {
+	d := NewDigest()
+	require.NotNil(t, d)
+	require.Equal(t, TagDigest, d.Tag())
+}
+
+func TestDigest(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		tag       reflect.StructTag
+		want      any
+		notExists bool
+	}{
+		{
+			name:   "Username",
+			header: testDigestHeader,
+			tag:    `digest:"username"`,
+			want:   "Mufasa",
+		},
+		{
+			name:   "Realm",
+			header: testDigestHeader,
+			tag:    `digest:"realm"`,
+			want:   "http-auth@example.org",
+		},
+		{
+			name:   "Quoted value with comma",
+			header: testDigestHeader,
+			tag:    `digest:"uri"`,
+			want:   "/dir/index.html?a=1,2",
+		},
+		{
+			name:   "Token value",
+			header: testDigestHeader,
+			tag:    `digest:"nc"`,
+			want:   "00000001",
+		},
+		{
+			name:   "Case insensitive",
+			header: `digest Username="bob",Realm="api"`,
+			tag:    `digest:"REALM"`,
+			want:   "api",
+		},
+		{
+			name:   "Escaped quote",
+			header: `Digest username="b\"ob", realm="api"`,
+			tag:    `digest:"username"`,
+			want:   `b"ob`,
+		},
+		{
+			name:   "All parameters",
+			header: `Digest username="bob", qop=auth, nonce="abc"`,
+			tag:    `digest:"*"`,
+			want:   map[string]string{"username": "bob", "qop": "auth", "nonce": "abc"},
+		},
+		{
+			name:      "Missing parameter",
+			header:    `Digest username="bob"`,
+			tag:       `digest:"nonce"`,
+			notExists: true,
+		},
+		{
+			name:      "Basic scheme",
+			header:    "Basic Ym9iOnNlY3JldA==",
+			tag:       `digest:"username"`,
+			notExists: true,
+		},
+		{
+			name:      "Missing header",
+			tag:       `digest:"username"`,
+			notExists: true,
+		},
+		{
+			name:      "Missing tag",
+			header:    testDigestHeader,
+			tag:       `header:"Authorization"`,
+			notExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+			require.NoError(t, err)
+
+			if len(tt.header) > 0 {
+				req.Header.Set(HeaderAuthorization, tt.header)
+			}
+
+			got, exists := NewDigest().Parse(req, tt.tag, make(Cache))
+			if tt.notExists {
+				require.False(t, exists)
+				return
+			}
+
+			require.True(t, exists)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
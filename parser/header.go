@@ -11,31 +11,97 @@ const (
 	TagHeader = "header"
 )
 
+// HeaderOptionsFunc header options changer.
+type HeaderOptionsFunc func(*Header)
+
+// WithHeaderSplit enables splitting of comma-separated header values.
+func WithHeaderSplit() HeaderOptionsFunc {
+	return func(h *Header) {
+		h.split = true
+	}
+}
+
+// WithHeaderSplitSymbol enables splitting of header values by split symbol.
+func WithHeaderSplitSymbol(splitSymbol string) HeaderOptionsFunc {
+	return func(h *Header) {
+		h.split = true
+		h.splitSymbol = splitSymbol
+	}
+}
+
+// WithRawHeaderKeys enables case-sensitive exact matching of header names
+// instead of canonical MIME header keys.
+func WithRawHeaderKeys() HeaderOptionsFunc {
+	return func(h *Header) {
+		h.rawKeys = true
+	}
+}
+
+// SplitValue value consisting of many parts.
+//
+// Slice fields receive all parts, other fields receive raw value.
+type SplitValue struct {
+	Raw    string
+	Values []string
+}
+
+// String returns raw value.
+func (s SplitValue) String() string {
+	return s.Raw
+}
+
+// Strings returns split parts of value.
+func (s SplitValue) Strings() []string {
+	return s.Values
+}
+
 // Header is a header parser.
-type Header struct{}
+type Header struct {
+	split       bool
+	rawKeys     bool
+	splitSymbol string
+}
 
 // NewHeader returns new header parser.
-func NewHeader() *Header {
-	return &Header{}
+func NewHeader(opts ...HeaderOptionsFunc) *Header {
+	h := Header{splitSymbol: SplitSymbol}
+
+	for _, opt := range opts {
+		opt(&h)
+	}
+
+	return &h
 }
 
 // Parse parse header.
-func (h *Header) Parse(r *http.Request, tag reflect.StructTag, _ Cache) (any, bool) {
+//
+// Header is looked up by canonical form of its name, then by raw name from tag,
+// with enabled raw header keys only raw name is used.
+//
+// With enabled split, values of header are split by split symbol and trimmed as list elements
+// according to RFC 7230, header appearing multiple times is combined into one list.
+//
+// Missing header is looked up by field name matcher set by roamer.WithFieldNameMatcher.
+//
+// Tag value `*` returns all headers as http.Header including hop-by-hop ones,
+// the field receives a copy of them, e.g. field of http.Header or map[string][]string type.
+func (h *Header) Parse(r *http.Request, tag reflect.StructTag, cache Cache) (any, bool) {
 	tagValue, ok := tag.Lookup(TagHeader)
 	if !ok {
 		return "", false
 	}
 
-	if strings.Contains(tagValue, SplitSymbol) {
-		return h.manyValues(r, tagValue)
+	tagValue, _ = SplitTagValue(tagValue)
+
+	if tagValue == TagValueAll {
+		return r.Header, len(r.Header) > 0
 	}
 
-	headerValue := r.Header.Get(tagValue)
-	if len(headerValue) == 0 {
-		return "", false
+	if strings.Contains(tagValue, SplitSymbol) {
+		return h.manyValues(r, tagValue, cache)
 	}
 
-	return headerValue, true
+	return h.value(r, tagValue, cache)
 }
 
 // Tag returns working tag.
@@ -43,15 +109,53 @@ func (h *Header) Tag() string {
 	return TagHeader
 }
 
-func (h *Header) manyValues(r *http.Request, tagValue string) (string, bool) {
+func (h *Header) manyValues(r *http.Request, tagValue string, cache Cache) (any, bool) {
 	for _, v := range strings.Split(tagValue, SplitSymbol) {
-		headerValue := r.Header.Get(strings.TrimSpace(v))
-		if len(headerValue) == 0 {
-			continue
+		if headerValue, ok := h.value(r, strings.TrimSpace(v), cache); ok {
+			return headerValue, true
 		}
-
-		return headerValue, true
 	}
 
 	return "", false
 }
+
+func (h *Header) value(r *http.Request, name string, cache Cache) (any, bool) {
+	headerValues := h.values(r.Header, name, cache)
+	if len(headerValues) == 0 || len(headerValues[0]) == 0 {
+		return "", false
+	}
+
+	if !h.split {
+		return headerValues[0], true
+	}
+
+	values := make([]string, 0, len(headerValues))
+	for _, headerValue := range headerValues {
+		for _, v := range strings.Split(headerValue, h.splitSymbol) {
+			if v = strings.TrimSpace(v); len(v) > 0 {
+				values = append(values, v)
+			}
+		}
+	}
+
+	return SplitValue{Raw: headerValues[0], Values: values}, true
+}
+
+func (h *Header) values(header http.Header, name string, cache Cache) []string {
+	values := header[name]
+	if !h.rawKeys {
+		if canonical := header.Values(name); len(canonical) > 0 {
+			values = canonical
+		}
+	}
+
+	if len(values) > 0 {
+		return values
+	}
+
+	if key, ok := matchKey(header, name, cache); ok {
+		return header[key]
+	}
+
+	return nil
+}
@@ -15,6 +15,144 @@ func TestNewHeader(t *testing.T) {
 	h := NewHeader()
 	require.NotNil(t, h)
 	require.Equal(t, TagHeader, h.Tag())
+	require.False(t, h.split)
+
+	h = NewHeader(WithHeaderSplit())
+	require.True(t, h.split)
+	require.Equal(t, SplitSymbol, h.splitSymbol)
+
+	h = NewHeader(WithHeaderSplitSymbol(";"))
+	require.True(t, h.split)
+	require.Equal(t, ";", h.splitSymbol)
+
+	h = NewHeader(WithRawHeaderKeys())
+	require.True(t, h.rawKeys)
+}
+
+func TestHeader_Keys(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    http.Header
+		tag       reflect.StructTag
+		opts      []HeaderOptionsFunc
+		want      any
+		notExists bool
+	}{
+		{
+			name:   "Lowercase tag with canonical header",
+			header: http.Header{"X-Request-Id": {"1"}},
+			tag:    `header:"x-request-id"`,
+			want:   "1",
+		},
+		{
+			name:   "Mixed-case tag with non-canonical header",
+			header: http.Header{"X-Request-ID": {"1"}},
+			tag:    `header:"X-Request-ID"`,
+			want:   "1",
+		},
+		{
+			name:      "Lowercase tag with non-canonical header",
+			header:    http.Header{"X-Request-ID": {"1"}},
+			tag:       `header:"x-request-id"`,
+			notExists: true,
+		},
+		{
+			name:   "Raw keys exact match",
+			header: http.Header{"x-REQUEST-id": {"1"}},
+			tag:    `header:"x-REQUEST-id"`,
+			opts:   []HeaderOptionsFunc{WithRawHeaderKeys()},
+			want:   "1",
+		},
+		{
+			name:      "Raw keys are case-sensitive",
+			header:    http.Header{"X-Request-Id": {"1"}},
+			tag:       `header:"x-request-id"`,
+			opts:      []HeaderOptionsFunc{WithRawHeaderKeys()},
+			notExists: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, requestURL, nil)
+			require.NoError(t, err)
+			req.Header = tt.header
+
+			h := NewHeader(tt.opts...)
+			value, exists := h.Parse(req, tt.tag, nil)
+			if tt.notExists {
+				require.False(t, exists)
+				return
+			}
+
+			require.True(t, exists)
+			require.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestHeader_Split(t *testing.T) {
+	newRequest := func(t *testing.T, header http.Header) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, requestURL, nil)
+		require.NoError(t, err)
+		req.Header = header
+
+		return req
+	}
+
+	tests := []struct {
+		name   string
+		header http.Header
+		opts   []HeaderOptionsFunc
+		want   any
+	}{
+		{
+			name:   "Split comma-separated values",
+			header: http.Header{"X-Tags": {"a, b ,c"}},
+			opts:   []HeaderOptionsFunc{WithHeaderSplit()},
+			want:   SplitValue{Raw: "a, b ,c", Values: []string{"a", "b", "c"}},
+		},
+		{
+			name:   "Split header appearing multiple times",
+			header: http.Header{"X-Tags": {"a, b", "c"}},
+			opts:   []HeaderOptionsFunc{WithHeaderSplit()},
+			want:   SplitValue{Raw: "a, b", Values: []string{"a", "b", "c"}},
+		},
+		{
+			name:   "Split skips empty list elements",
+			header: http.Header{"X-Tags": {"a,,b,"}},
+			opts:   []HeaderOptionsFunc{WithHeaderSplit()},
+			want:   SplitValue{Raw: "a,,b,", Values: []string{"a", "b"}},
+		},
+		{
+			name:   "Split by custom symbol",
+			header: http.Header{"X-Tags": {"a;b"}},
+			opts:   []HeaderOptionsFunc{WithHeaderSplitSymbol(";")},
+			want:   SplitValue{Raw: "a;b", Values: []string{"a", "b"}},
+		},
+		{
+			name:   "Single value",
+			header: http.Header{"X-Tags": {"a"}},
+			opts:   []HeaderOptionsFunc{WithHeaderSplit()},
+			want:   SplitValue{Raw: "a", Values: []string{"a"}},
+		},
+		{
+			name:   "Split disabled",
+			header: http.Header{"X-Tags": {"a, b"}},
+			want:   "a, b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHeader(tt.opts...)
+			value, exists := h.Parse(newRequest(t, tt.header), `header:"X-Tags"`, nil)
+			require.True(t, exists)
+			require.Equal(t, tt.want, value)
+		})
+	}
 }
 
 func TestHeader(t *testing.T) {
@@ -122,3 +260,23 @@ func TestHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestHeader_All(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	require.NoError(t, err)
+
+	value, exists := NewHeader().Parse(req, `header:"*"`, nil)
+	require.False(t, exists)
+	require.Empty(t, value)
+
+	req.Header.Add("Accept", "text/html")
+	req.Header.Add("Accept", "application/json")
+	req.Header.Set("Connection", "keep-alive")
+
+	value, exists = NewHeader().Parse(req, `header:"*"`, nil)
+	require.True(t, exists)
+	require.Equal(t, http.Header{
+		"Accept":     {"text/html", "application/json"},
+		"Connection": {"keep-alive"},
+	}, value)
+}
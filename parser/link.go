@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	// TagLink link tag.
+	TagLink = "link"
+	// HeaderLink default header of links.
+	HeaderLink = "Link"
+)
+
+// LinkValue is a link of RFC 8288 Link header.
+type LinkValue struct {
+	// URI target of link.
+	URI string
+	// Rel relation type of link.
+	Rel string
+	// Title title of link.
+	Title string
+	// Params all parameters of link including rel and title, keys are lowercase.
+	Params map[string]string
+}
+
+// Link is a parser of RFC 8288 Link headers into []LinkValue, e.g. `link:"Link"`.
+type Link struct{}
+
+// NewLink returns new link parser.
+func NewLink() *Link {
+	return &Link{}
+}
+
+// Parse parses links from http header.
+func (l *Link) Parse(r *http.Request, tag reflect.StructTag, _ Cache) (any, bool) {
+	tagValue, ok := tag.Lookup(TagLink)
+	if !ok {
+		return nil, false
+	}
+
+	tagValue, _ = SplitTagValue(tagValue)
+	if len(tagValue) == 0 {
+		tagValue = HeaderLink
+	}
+
+	var links []LinkValue
+	for _, header := range r.Header.Values(tagValue) {
+		links = parseLinks(links, header)
+	}
+
+	if len(links) == 0 {
+		return nil, false
+	}
+
+	return links, true
+}
+
+// Tag returns working tag.
+func (l *Link) Tag() string {
+	return TagLink
+}
+
+// parseLinks parses links of header value, e.g. `<https://a.com/2>; rel="next", <https://a.com/1>; rel=prev`.
+func parseLinks(links []LinkValue, header string) []LinkValue {
+	for {
+		start := strings.IndexByte(header, '<')
+		if start < 0 {
+			return links
+		}
+
+		end := strings.IndexByte(header[start:], '>')
+		if end < 0 {
+			return links
+		}
+
+		link := LinkValue{URI: strings.TrimSpace(header[start+1 : start+end])}
+		header = header[start+end+1:]
+
+		// params of link last until comma outside of quotes.
+		for {
+			header = strings.TrimLeft(header, " \t")
+			if len(header) == 0 || header[0] != ';' {
+				break
+			}
+
+			var name, value string
+			name, value, header = parseLinkParam(header[1:])
+			if len(name) == 0 {
+				continue
+			}
+
+			if link.Params == nil {
+				link.Params = make(map[string]string)
+			}
+
+			link.Params[name] = value
+
+			switch name {
+			case "rel":
+				link.Rel = value
+			case "title":
+				link.Title = value
+			}
+		}
+
+		links = append(links, link)
+	}
+}
+
+// parseLinkParam parses single `name="value"` link param, returns rest of header.
+func parseLinkParam(header string) (name, value, rest string) {
+	header = strings.TrimLeft(header, " \t")
+
+	end := strings.IndexAny(header, "=;,")
+	if end < 0 {
+		return strings.ToLower(strings.TrimSpace(header)), "", ""
+	}
+
+	name = strings.ToLower(strings.TrimSpace(header[:end]))
+	if header[end] != '=' {
+		return name, "", header[end:]
+	}
+
+	header = strings.TrimLeft(header[end+1:], " \t")
+	if len(header) > 0 && header[0] == '"' {
+		var b strings.Builder
+		for i := 1; i < len(header); i++ {
+			switch c := header[i]; c {
+			case '\\':
+				if i+1 < len(header) {
+					i++
+					b.WriteByte(header[i])
+				}
+			case '"':
+				return name, b.String(), header[i+1:]
+			default:
+				b.WriteByte(c)
+			}
+		}
+
+		return name, b.String(), ""
+	}
+
+	end = strings.IndexAny(header, ";,")
+	if end < 0 {
+		return name, strings.TrimSpace(header), ""
+	}
+
+	return name, strings.TrimSpace(header[:end]), header[end:]
+}
@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLink(t *testing.T) {
+	l := NewLink()
+	require.NotNil(t, l)
+	require.Equal(t, TagLink, l.Tag())
+}
+
+func TestLink(t *testing.T) {
+	tests := []struct {
+		name      string
+		headers   []string
+		tag       reflect.StructTag
+		want      []LinkValue
+		notExists bool
+	}{
+		{
+			name:    "Multiple links with rel and title",
+			headers: []string{`<https://example.com/?page=2>; rel="next"; title="Next, page", <https://example.com/?page=1>; rel=prev; title=Previous`},
+			tag:     `link:"Link"`,
+			want: []LinkValue{
+				{
+					URI:    "https://example.com/?page=2",
+					Rel:    "next",
+					Title:  "Next, page",
+					Params: map[string]string{"rel": "next", "title": "Next, page"},
+				},
+				{
+					URI:    "https://example.com/?page=1",
+					Rel:    "prev",
+					Title:  "Previous",
+					Params: map[string]string{"rel": "prev", "title": "Previous"},
+				},
+			},
+		},
+		{
+			name:    "Repeated headers",
+			headers: []string{`<https://example.com/a>; REL="alternate"; hreflang=de`, `<https://example.com/b>`},
+			tag:     `link:""`,
+			want: []LinkValue{
+				{
+					URI:    "https://example.com/a",
+					Rel:    "alternate",
+					Params: map[string]string{"rel": "alternate", "hreflang": "de"},
+				},
+				{URI: "https://example.com/b"},
+			},
+		},
+		{
+			name:    "Escaped quote in title",
+			headers: []string{`</a>; rel=self; title="say \"hi\""`},
+			tag:     `link:"Link"`,
+			want: []LinkValue{
+				{
+					URI:    "/a",
+					Rel:    "self",
+					Title:  `say "hi"`,
+					Params: map[string]string{"rel": "self", "title": `say "hi"`},
+				},
+			},
+		},
+		{
+			name:      "No header",
+			tag:       `link:"Link"`,
+			notExists: true,
+		},
+		{
+			name:      "Wrong tag",
+			headers:   []string{`</a>; rel=self`},
+			tag:       `header:"Link"`,
+			notExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+			require.NoError(t, err)
+
+			for _, h := range tt.headers {
+				req.Header.Add(HeaderLink, h)
+			}
+
+			v, exists := NewLink().Parse(req, tt.tag, nil)
+			if tt.notExists {
+				require.False(t, exists)
+				return
+			}
+
+			require.True(t, exists)
+			require.Equal(t, tt.want, v)
+		})
+	}
+}
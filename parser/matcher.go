@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	// CacheKeyFieldNameMatcher cache key of matcher of request keys, it is set by roamer.WithFieldNameMatcher.
+	CacheKeyFieldNameMatcher = "field_name_matcher"
+)
+
+// FieldNameMatcher reports whether key of request data matches key of tag, e.g. `userId` matches `user_id`.
+type FieldNameMatcher = func(tag, incoming string) bool
+
+// LooseMatch matches keys ignoring case, underscores and dashes, e.g. `user_id`, `userId` and `USERID`.
+func LooseMatch(tag, incoming string) bool {
+	return strings.EqualFold(looseKey(tag), looseKey(incoming))
+}
+
+// looseKey removes underscores and dashes from key.
+func looseKey(key string) string {
+	if !strings.ContainsAny(key, "_-") {
+		return key
+	}
+
+	return strings.NewReplacer("_", "", "-", "").Replace(key)
+}
+
+// matchKey returns the first in sorted order of keys matched by matcher of cache,
+// false is returned without matcher.
+func matchKey[V any](m map[string]V, name string, cache Cache) (string, bool) {
+	match, ok := cache[CacheKeyFieldNameMatcher].(FieldNameMatcher)
+	if !ok || match == nil {
+		return "", false
+	}
+
+	var matched []string
+	for k := range m {
+		if match(name, k) {
+			matched = append(matched, k)
+		}
+	}
+
+	if len(matched) == 0 {
+		return "", false
+	}
+
+	return slices.Min(matched), true
+}
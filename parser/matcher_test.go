@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLooseMatch(t *testing.T) {
+	tests := []struct {
+		tag      string
+		incoming string
+		want     bool
+	}{
+		{tag: "user_id", incoming: "user_id", want: true},
+		{tag: "user_id", incoming: "userId", want: true},
+		{tag: "user_id", incoming: "USERID", want: true},
+		{tag: "user_id", incoming: "user-id", want: true},
+		{tag: "X-Request-Id", incoming: "x_request_id", want: true},
+		{tag: "user_id", incoming: "user_ids", want: false},
+		{tag: "user_id", incoming: "id", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag+"/"+tt.incoming, func(t *testing.T) {
+			require.Equal(t, tt.want, LooseMatch(tt.tag, tt.incoming))
+		})
+	}
+}
+
+func TestMatchKey(t *testing.T) {
+	query := url.Values{"userId": {"1"}, "USERID": {"2"}, "page": {"3"}}
+
+	key, ok := matchKey(query, "user_id", Cache{CacheKeyFieldNameMatcher: LooseMatch})
+	require.True(t, ok)
+	require.Equal(t, "USERID", key, "first key in sorted order")
+
+	_, ok = matchKey(query, "sort", Cache{CacheKeyFieldNameMatcher: LooseMatch})
+	require.False(t, ok)
+
+	_, ok = matchKey(query, "user_id", Cache{})
+	require.False(t, ok)
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"crypto/md5"  //nolint:gosec // checksum, not a security measure
+	"crypto/sha1" //nolint:gosec // checksum, not a security measure
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	// TagMeta meta tag.
+	TagMeta = "meta"
+	// MetaBodyLength meta key of amount of body bytes consumed by decoder.
+	MetaBodyLength = "body_length"
+	// MetaRawQuery meta key of raw query of request url without `?`.
+	MetaRawQuery = "raw_query"
+	// MetaCharset meta key of lowercase charset parameter of Content-Type header, DefaultCharset if it is missing.
+	MetaCharset = "charset"
+	// DefaultCharset charset of request without charset parameter of Content-Type header.
+	DefaultCharset = "utf-8"
+	// MetaBodyMD5 meta key of hex encoded MD5 checksum of preserved body.
+	MetaBodyMD5 = "body_md5"
+	// MetaBodySHA1 meta key of hex encoded SHA-1 checksum of preserved body.
+	MetaBodySHA1 = "body_sha1"
+	// MetaBodySHA256 meta key of hex encoded SHA-256 checksum of preserved body.
+	MetaBodySHA256 = "body_sha256"
+	// MetaBodySHA512 meta key of hex encoded SHA-512 checksum of preserved body.
+	MetaBodySHA512 = "body_sha512"
+	// CacheKeyBodyLength cache key of amount of body bytes consumed by decoder.
+	CacheKeyBodyLength = "meta_body_length"
+	// CacheKeyBody cache key of preserved body.
+	CacheKeyBody = "meta_body"
+)
+
+// bodyHashes hash constructors by meta keys.
+var bodyHashes = map[string]func() hash.Hash{
+	MetaBodyMD5:    md5.New,
+	MetaBodySHA1:   sha1.New,
+	MetaBodySHA256: sha256.New,
+	MetaBodySHA512: sha512.New,
+}
+
+// IsMetaBodyHash reports whether meta key is a checksum of preserved body.
+func IsMetaBodyHash(key string) bool {
+	_, ok := bodyHashes[key]
+	return ok
+}
+
+// Meta is a parser of request metadata.
+type Meta struct{}
+
+// NewMeta returns new meta parser.
+func NewMeta() *Meta {
+	return &Meta{}
+}
+
+// Parse parses request metadata.
+func (m *Meta) Parse(r *http.Request, tag reflect.StructTag, cache Cache) (any, bool) {
+	tagValue, ok := tag.Lookup(TagMeta)
+	if !ok {
+		return "", false
+	}
+
+	switch tagValue {
+	case MetaBodyLength:
+		length, ok := cache[CacheKeyBodyLength].(int64)
+		if !ok {
+			return "", false
+		}
+
+		return length, true
+	case MetaRawQuery:
+		if r.URL == nil || len(r.URL.RawQuery) == 0 {
+			return "", false
+		}
+
+		return r.URL.RawQuery, true
+	case MetaCharset:
+		return contentTypeCharset(r.Header.Get("Content-Type")), true
+	}
+
+	if newHash, ok := bodyHashes[tagValue]; ok {
+		body, ok := cache[CacheKeyBody].([]byte)
+		if !ok {
+			return "", false
+		}
+
+		h := newHash()
+		h.Write(body)
+
+		return hex.EncodeToString(h.Sum(nil)), true
+	}
+
+	return "", false
+}
+
+// Tag returns working tag.
+func (m *Meta) Tag() string {
+	return TagMeta
+}
+
+// contentTypeCharset returns lowercase charset parameter of content type or DefaultCharset.
+func contentTypeCharset(contentType string) string {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return DefaultCharset
+	}
+
+	charset, ok := params["charset"]
+	if !ok || len(charset) == 0 {
+		return DefaultCharset
+	}
+
+	return strings.ToLower(charset)
+}
@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMeta(t *testing.T) {
+	m := NewMeta()
+	require.NotNil(t, m)
+	require.Equal(t, TagMeta, m.Tag())
+}
+
+func TestMeta(t *testing.T) {
+	type args struct {
+		tag   reflect.StructTag
+		cache Cache
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      any
+		notExists bool
+	}{
+		{
+			name: "Get body length",
+			args: args{
+				tag:   reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagMeta, MetaBodyLength)),
+				cache: Cache{CacheKeyBodyLength: int64(42)},
+			},
+			want: int64(42),
+		},
+		{
+			name: "Get body length - body not decoded",
+			args: args{
+				tag:   reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagMeta, MetaBodyLength)),
+				cache: Cache{},
+			},
+			notExists: true,
+		},
+		{
+			name: "Get body sha256",
+			args: args{
+				tag:   reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagMeta, MetaBodySHA256)),
+				cache: Cache{CacheKeyBody: []byte("test")},
+			},
+			want: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		},
+		{
+			name: "Get body md5",
+			args: args{
+				tag:   reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagMeta, MetaBodyMD5)),
+				cache: Cache{CacheKeyBody: []byte("test")},
+			},
+			want: "098f6bcd4621d373cade4e832627b4f6",
+		},
+		{
+			name: "Get body sha256 - body not preserved",
+			args: args{
+				tag:   reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagMeta, MetaBodySHA256)),
+				cache: Cache{},
+			},
+			notExists: true,
+		},
+		{
+			name: "Unknown meta key",
+			args: args{
+				tag:   reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagMeta, "unknown")),
+				cache: Cache{},
+			},
+			notExists: true,
+		},
+		{
+			name: "Wrong tag",
+			args: args{
+				tag:   reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagQuery, MetaBodyLength)),
+				cache: Cache{CacheKeyBodyLength: int64(42)},
+			},
+			notExists: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, requestURL, nil)
+			require.NoError(t, err)
+
+			value, exists := NewMeta().Parse(req, tt.args.tag, tt.args.cache)
+			if tt.notExists {
+				require.False(t, exists)
+				return
+			}
+
+			require.True(t, exists)
+			require.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestMeta_RawQuery(t *testing.T) {
+	const rawQuery = "b=2&a=1&a=%20x&empty="
+
+	tag := reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagMeta, MetaRawQuery))
+
+	req, err := http.NewRequest(http.MethodGet, requestURL+"?"+rawQuery, nil)
+	require.NoError(t, err)
+
+	value, exists := NewMeta().Parse(req, tag, Cache{})
+	require.True(t, exists)
+	require.Equal(t, rawQuery, value)
+
+	req, err = http.NewRequest(http.MethodGet, requestURL, nil)
+	require.NoError(t, err)
+
+	_, exists = NewMeta().Parse(req, tag, Cache{})
+	require.False(t, exists)
+}
+
+func TestMeta_Charset(t *testing.T) {
+	tag := reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagMeta, MetaCharset))
+
+	tests := []struct {
+		name        string
+		contentType string
+		want        string
+	}{
+		{
+			name:        "Explicit charset",
+			contentType: "text/plain; charset=ISO-8859-1",
+			want:        "iso-8859-1",
+		},
+		{
+			name:        "Quoted charset",
+			contentType: `application/json; charset="windows-1251"`,
+			want:        "windows-1251",
+		},
+		{
+			name:        "Default without charset",
+			contentType: "application/json",
+			want:        DefaultCharset,
+		},
+		{
+			name: "Default without content type",
+			want: DefaultCharset,
+		},
+		{
+			name:        "Default of invalid content type",
+			contentType: "text/plain; charset",
+			want:        DefaultCharset,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, requestURL, nil)
+			require.NoError(t, err)
+
+			if len(tt.contentType) > 0 {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			value, exists := NewMeta().Parse(req, tag, Cache{})
+			require.True(t, exists)
+			require.Equal(t, tt.want, value)
+		})
+	}
+}
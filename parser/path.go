@@ -34,6 +34,8 @@ func (p *Path) Parse(r *http.Request, tag reflect.StructTag, _ Cache) (any, bool
 		return "", false
 	}
 
+	tagValue, _ = SplitTagValue(tagValue)
+
 	return p.valueFromPath(r, tagValue)
 }
 
@@ -1,9 +1,12 @@
 package parser
 
 import (
+	"cmp"
 	"net/http"
 	"net/url"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -11,8 +14,19 @@ const (
 	// TagQuery query tag.
 	TagQuery = "query"
 	// SplitSymbol array split symbol.
-	SplitSymbol   = ","
-	cacheKeyQuery = "query"
+	SplitSymbol = ","
+	// TagValueAll tag value for catching all values of a source.
+	TagValueAll           = "*"
+	cacheKeyQuery         = "query"
+	cacheKeyQueryConsumed = "query_consumed"
+	cacheKeyQueryIndexed  = "query_indexed"
+	cacheKeyQuerySuffixed = "query_suffixed"
+	// suffixSeparator separator of numeric suffix of query keys, e.g. `tag.1`.
+	suffixSeparator = "."
+	// IndexedArraysMaxIndex default max index of query keys with explicit indexes.
+	IndexedArraysMaxIndex = 1000
+	// DottedMapsMaxDepth default max depth of nested maps built from dotted query keys.
+	DottedMapsMaxDepth = 4
 )
 
 // QueryOptionsFunc query options changer.
@@ -32,15 +46,117 @@ func WithSplitSymbol(splitSymbol string) QueryOptionsFunc {
 	}
 }
 
+// WithAutoSplit enables array splitting by the first of delimiters present in value,
+// e.g. both `ids=1|2|3` and `ids=1,2,3` are split with `parser.WithAutoSplit('|', ',')`.
+//
+// Delimiters are checked in given order, so for value with several delimiters the earliest one wins
+// and the others are kept in elements, e.g. `a|b,c` is split into `a` and `b,c`.
+func WithAutoSplit(delimiters ...rune) QueryOptionsFunc {
+	return func(q *Query) {
+		q.split = true
+		q.autoSplit = delimiters
+	}
+}
+
+// WithDecodePlus sets whether `+` in query is decoded as space, it is decoded by default.
+//
+// Without decoding `+` is kept as is, e.g. for base64 values, space can still be sent as `%20`.
+func WithDecodePlus(decode bool) QueryOptionsFunc {
+	return func(q *Query) {
+		q.keepPlus = !decode
+	}
+}
+
+// DuplicatePolicy policy of choosing value of duplicate query keys for scalar fields.
+type DuplicatePolicy uint8
+
+const (
+	// DuplicateFirst first value of duplicate query keys is used.
+	DuplicateFirst DuplicatePolicy = iota + 1
+	// DuplicateLast last value of duplicate query keys is used.
+	DuplicateLast
+)
+
+// WithDuplicatePolicy sets policy of choosing value of duplicate query keys for scalar fields,
+// slice fields still receive all values.
+func WithDuplicatePolicy(policy DuplicatePolicy) QueryOptionsFunc {
+	return func(q *Query) {
+		q.duplicatePolicy = policy
+	}
+}
+
+// WithIndexedArrays enables query keys with explicit indexes for slice fields,
+// e.g. `items[0]=a&items[2]=c` fills `query:"items"` with `a`, empty string and `c`.
+//
+// Slice is sized to the max index, gaps are left as zero values.
+// Keys with index greater than IndexedArraysMaxIndex are ignored, so a client can't make a huge slice.
+// Keys without index take precedence over keys with indexes.
+func WithIndexedArrays() QueryOptionsFunc {
+	return func(q *Query) {
+		q.indexedArrays = true
+	}
+}
+
+// WithIndexedArraysMaxIndex sets max index of query keys with explicit indexes, see WithIndexedArrays.
+func WithIndexedArraysMaxIndex(index int) QueryOptionsFunc {
+	return func(q *Query) {
+		q.indexedArraysMaxIndex = max(index, 0)
+	}
+}
+
+// WithSuffixIndexedArrays enables query keys with numeric suffixes for slice fields,
+// e.g. `tag.2=web&tag.1=go` fills `query:"tag"` with `go`, `web`.
+//
+// Values are ordered by suffixes, gaps between suffixes are skipped.
+// Keys without suffix take precedence over keys with suffixes.
+func WithSuffixIndexedArrays() QueryOptionsFunc {
+	return func(q *Query) {
+		q.suffixIndexedArrays = true
+	}
+}
+
+// WithDottedMaps enables query keys with dot separated parts for map fields,
+// e.g. `labels.env.region=us` fills `query:"labels"` map[string]map[string]string with {"env": {"region": "us"}}.
+//
+// Depth of nested maps is limited by DottedMapsMaxDepth, the rest of key beyond the limit
+// including dots is a key of the deepest map, e.g. `a.b.c` of `labels.a.b.c` with max depth of 2.
+// Key with both value and nested keys, e.g. `labels.env=x&labels.env.region=us`, keeps only nested keys.
+// Keys without dots take precedence over dotted keys.
+func WithDottedMaps() QueryOptionsFunc {
+	return func(q *Query) {
+		q.dottedMaps = true
+	}
+}
+
+// WithDottedMapsMaxDepth sets max depth of nested maps built from dotted query keys, see WithDottedMaps.
+func WithDottedMapsMaxDepth(depth int) QueryOptionsFunc {
+	return func(q *Query) {
+		q.dottedMapsMaxDepth = max(depth, 1)
+	}
+}
+
 // Query query parser.
 type Query struct {
-	split       bool
-	splitSymbol string
+	split                 bool
+	splitSymbol           string
+	autoSplit             []rune
+	keepPlus              bool
+	duplicatePolicy       DuplicatePolicy
+	indexedArrays         bool
+	indexedArraysMaxIndex int
+	suffixIndexedArrays   bool
+	dottedMaps            bool
+	dottedMapsMaxDepth    int
 }
 
 // NewQuery returns new query parser.
 func NewQuery(opts ...QueryOptionsFunc) *Query {
-	q := Query{split: true, splitSymbol: SplitSymbol}
+	q := Query{
+		split:                 true,
+		splitSymbol:           SplitSymbol,
+		indexedArraysMaxIndex: IndexedArraysMaxIndex,
+		dottedMapsMaxDepth:    DottedMapsMaxDepth,
+	}
 
 	for _, opt := range opts {
 		opt(&q)
@@ -52,35 +168,344 @@ func NewQuery(opts ...QueryOptionsFunc) *Query {
 // Parse parses query from request.
 //
 // If query is not found in cache it will be parsed from request url and cached.
+//
+// Tag value `*` returns all query values which were not consumed by other fields as url.Values.
+// Missing key is looked up by field name matcher set by roamer.WithFieldNameMatcher.
 func (q *Query) Parse(r *http.Request, tag reflect.StructTag, cache Cache) (any, bool) {
 	tagValue, ok := tag.Lookup(TagQuery)
 	if !ok {
 		return "", false
 	}
 
+	tagValue, _ = SplitTagValue(tagValue)
+
 	query, ok := cache[cacheKeyQuery].(url.Values)
 	if !ok {
-		query = r.URL.Query()
+		query = q.query(r.URL)
 		cache[cacheKeyQuery] = query
 	}
 
+	if tagValue == TagValueAll {
+		return q.notConsumed(query, cache)
+	}
+
 	values, ok := query[tagValue]
 	if !ok {
+		if key, matched := matchKey(query, tagValue, cache); matched {
+			tagValue, values, ok = key, query[key], true
+		}
+	}
+
+	if !ok {
+		if q.indexedArrays {
+			if indexed, ok := q.indexed(query, tagValue, cache); ok {
+				return indexed, true
+			}
+		}
+
+		if q.suffixIndexedArrays {
+			if suffixed, ok := q.suffixIndexed(query, tagValue, cache); ok {
+				return suffixed, true
+			}
+		}
+
+		if q.dottedMaps {
+			return q.dotted(query, tagValue, cache)
+		}
+
 		return "", false
 	}
 
+	consume(query, cache, tagValue)
+
 	if len(values) == 1 {
-		if q.split && strings.Contains(values[0], q.splitSymbol) {
-			return strings.Split(values[0], q.splitSymbol), true
+		if q.split {
+			if splitSymbol, ok := q.splitSymbolOf(values[0]); ok {
+				return strings.Split(values[0], splitSymbol), true
+			}
 		}
 
 		return values[0], true
 	}
 
+	switch q.duplicatePolicy {
+	case DuplicateFirst:
+		return SplitValue{Raw: values[0], Values: values}, true
+	case DuplicateLast:
+		return SplitValue{Raw: values[len(values)-1], Values: values}, true
+	}
+
 	return values, true
 }
 
+// Consume marks query keys of field as consumed without returning the value,
+// e.g. for a pre-filled field so its key is not caught by `query:"*"` field.
+func (q *Query) Consume(r *http.Request, tag reflect.StructTag, cache Cache) {
+	tagValue, ok := tag.Lookup(TagQuery)
+	if !ok {
+		return
+	}
+
+	if tagValue, _ = SplitTagValue(tagValue); tagValue == TagValueAll {
+		return
+	}
+
+	_, _ = q.Parse(r, tag, cache)
+}
+
 // Tag returns working tag.
 func (q *Query) Tag() string {
 	return TagQuery
 }
+
+// query returns query values of url.
+func (q *Query) query(u *url.URL) url.Values {
+	if !q.keepPlus {
+		return u.Query()
+	}
+
+	return parseQueryKeepPlus(u.RawQuery)
+}
+
+// parseQueryKeepPlus parses raw query like url.ParseQuery keeping `+` as is,
+// pairs with semicolons or invalid escapes are skipped.
+func parseQueryKeepPlus(rawQuery string) url.Values {
+	values := make(url.Values)
+	for len(rawQuery) > 0 {
+		var pair string
+		pair, rawQuery, _ = strings.Cut(rawQuery, "&")
+		if len(pair) == 0 || strings.Contains(pair, ";") {
+			continue
+		}
+
+		key, value, _ := strings.Cut(pair, "=")
+
+		key, err := url.PathUnescape(key)
+		if err != nil {
+			continue
+		}
+
+		value, err = url.PathUnescape(value)
+		if err != nil {
+			continue
+		}
+
+		values[key] = append(values[key], value)
+	}
+
+	return values
+}
+
+// splitSymbolOf returns split symbol present in value, with auto split the first of present delimiters.
+func (q *Query) splitSymbolOf(value string) (string, bool) {
+	if len(q.autoSplit) == 0 {
+		return q.splitSymbol, strings.Contains(value, q.splitSymbol)
+	}
+
+	for _, d := range q.autoSplit {
+		if strings.ContainsRune(value, d) {
+			return string(d), true
+		}
+	}
+
+	return "", false
+}
+
+// consume marks query keys as consumed by a field.
+func consume(query url.Values, cache Cache, keys ...string) {
+	consumed, ok := cache[cacheKeyQueryConsumed].(map[string]struct{})
+	if !ok {
+		consumed = make(map[string]struct{}, len(query))
+		cache[cacheKeyQueryConsumed] = consumed
+	}
+
+	for _, k := range keys {
+		consumed[k] = struct{}{}
+	}
+}
+
+// indexedValue value of query key with index, e.g. `items[2]`.
+type indexedValue struct {
+	key   string
+	index int
+	value string
+}
+
+// indexed returns slice of values of query keys with indexes placed by indexes.
+func (q *Query) indexed(query url.Values, name string, cache Cache) ([]string, bool) {
+	indexed, ok := cache[cacheKeyQueryIndexed].(map[string][]indexedValue)
+	if !ok {
+		indexed = indexQuery(query, q.indexedArraysMaxIndex)
+		cache[cacheKeyQueryIndexed] = indexed
+	}
+
+	values, ok := indexed[name]
+	if !ok {
+		return nil, false
+	}
+
+	size := 0
+	keys := make([]string, 0, len(values))
+	for _, v := range values {
+		size = max(size, v.index+1)
+		keys = append(keys, v.key)
+	}
+
+	result := make([]string, size)
+	for _, v := range values {
+		result[v.index] = v.value
+	}
+
+	consume(query, cache, keys...)
+
+	return result, true
+}
+
+// indexQuery groups values of query keys with indexes up to max index by names.
+func indexQuery(query url.Values, maxIndex int) map[string][]indexedValue {
+	indexed := make(map[string][]indexedValue)
+	for k, v := range query {
+		name, index, found := strings.Cut(k, "[")
+		if !found || len(v) == 0 {
+			continue
+		}
+
+		index, found = strings.CutSuffix(index, "]")
+		if !found {
+			continue
+		}
+
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i > maxIndex {
+			continue
+		}
+
+		indexed[name] = append(indexed[name], indexedValue{key: k, index: i, value: v[0]})
+	}
+
+	return indexed
+}
+
+// suffixIndexed returns slice of values of query keys with numeric suffixes ordered by suffixes.
+func (q *Query) suffixIndexed(query url.Values, name string, cache Cache) ([]string, bool) {
+	suffixed, ok := cache[cacheKeyQuerySuffixed].(map[string][]indexedValue)
+	if !ok {
+		suffixed = indexQuerySuffixes(query)
+		cache[cacheKeyQuerySuffixed] = suffixed
+	}
+
+	values, ok := suffixed[name]
+	if !ok {
+		return nil, false
+	}
+
+	slices.SortFunc(values, func(a, b indexedValue) int {
+		return cmp.Compare(a.index, b.index)
+	})
+
+	result := make([]string, 0, len(values))
+	keys := make([]string, 0, len(values))
+	for _, v := range values {
+		result = append(result, v.value)
+		keys = append(keys, v.key)
+	}
+
+	consume(query, cache, keys...)
+
+	return result, true
+}
+
+// indexQuerySuffixes groups values of query keys with numeric suffixes by names.
+func indexQuerySuffixes(query url.Values) map[string][]indexedValue {
+	suffixed := make(map[string][]indexedValue)
+	for k, v := range query {
+		i := strings.LastIndex(k, suffixSeparator)
+		if i <= 0 || len(v) == 0 {
+			continue
+		}
+
+		index, err := strconv.Atoi(k[i+1:])
+		if err != nil || index < 0 {
+			continue
+		}
+
+		name := k[:i]
+		suffixed[name] = append(suffixed[name], indexedValue{key: k, index: index, value: v[0]})
+	}
+
+	return suffixed
+}
+
+// dotted returns nested maps built from query keys with dot separated parts, e.g. `labels.env.region`,
+// nested maps are map[string]any and values are slices of strings.
+func (q *Query) dotted(query url.Values, name string, cache Cache) (map[string]any, bool) {
+	prefix := name + suffixSeparator
+
+	var (
+		result map[string]any
+		keys   []string
+	)
+
+	for k, v := range query {
+		path, found := strings.CutPrefix(k, prefix)
+		if !found || len(path) == 0 || len(v) == 0 {
+			continue
+		}
+
+		if result == nil {
+			result = make(map[string]any)
+		}
+
+		parts := strings.SplitN(path, suffixSeparator, q.dottedMapsMaxDepth)
+		setDotted(result, parts, v)
+		keys = append(keys, k)
+	}
+
+	if result == nil {
+		return nil, false
+	}
+
+	consume(query, cache, keys...)
+
+	return result, true
+}
+
+// setDotted sets values into nested map by parts of dotted key, nested keys replace values.
+func setDotted(m map[string]any, parts []string, values []string) {
+	for _, part := range parts[:len(parts)-1] {
+		nested, ok := m[part].(map[string]any)
+		if !ok {
+			nested = make(map[string]any)
+			m[part] = nested
+		}
+
+		m = nested
+	}
+
+	last := parts[len(parts)-1]
+	if _, ok := m[last].(map[string]any); ok {
+		return
+	}
+
+	m[last] = values
+}
+
+// notConsumed returns query values which were not consumed by other fields.
+func (q *Query) notConsumed(query url.Values, cache Cache) (url.Values, bool) {
+	consumed, _ := cache[cacheKeyQueryConsumed].(map[string]struct{})
+
+	values := make(url.Values, len(query))
+	for k, v := range query {
+		if _, ok := consumed[k]; ok {
+			continue
+		}
+
+		values[k] = v
+	}
+
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	return values, true
+}
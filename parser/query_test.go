@@ -22,6 +22,499 @@ func TestNewQuery(t *testing.T) {
 	q = NewQuery(WithSplitSymbol(";"))
 	require.NotNil(t, q)
 	require.Equal(t, ";", q.splitSymbol)
+
+	q = NewQuery(WithDuplicatePolicy(DuplicateLast))
+	require.NotNil(t, q)
+	require.Equal(t, DuplicateLast, q.duplicatePolicy)
+
+	q = NewQuery(WithIndexedArrays())
+	require.NotNil(t, q)
+	require.True(t, q.indexedArrays)
+
+	q = NewQuery(WithSuffixIndexedArrays())
+	require.NotNil(t, q)
+	require.True(t, q.suffixIndexedArrays)
+
+	q = NewQuery(WithDisabledSplit(), WithAutoSplit('|', ','))
+	require.NotNil(t, q)
+	require.True(t, q.split)
+	require.Equal(t, []rune{'|', ','}, q.autoSplit)
+
+	q = NewQuery(WithDottedMaps(), WithDottedMapsMaxDepth(0))
+	require.NotNil(t, q)
+	require.True(t, q.dottedMaps)
+	require.Equal(t, 1, q.dottedMapsMaxDepth)
+
+	q = NewQuery(WithIndexedArrays(), WithIndexedArraysMaxIndex(-1))
+	require.NotNil(t, q)
+	require.True(t, q.indexedArrays)
+	require.Equal(t, 0, q.indexedArraysMaxIndex)
+
+	q = NewQuery(WithDecodePlus(false))
+	require.NotNil(t, q)
+	require.True(t, q.keepPlus)
+
+	q = NewQuery(WithDecodePlus(true))
+	require.NotNil(t, q)
+	require.False(t, q.keepPlus)
+}
+
+func TestQuery_IndexedArrays(t *testing.T) {
+	tag := reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagQuery, "items"))
+
+	tests := []struct {
+		name      string
+		query     string
+		opts      []QueryOptionsFunc
+		want      any
+		notExists bool
+		ignored   bool
+	}{
+		{
+			name:  "Sparse indexes",
+			query: "items[0]=a&items[2]=c",
+			opts:  []QueryOptionsFunc{WithIndexedArrays()},
+			want:  []string{"a", "", "c"},
+		},
+		{
+			name:  "Out of order keys",
+			query: "items[2]=c&items[1]=b&items[0]=a",
+			opts:  []QueryOptionsFunc{WithIndexedArrays()},
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name:  "Key without index takes precedence",
+			query: "items=x&items[0]=a",
+			opts:  []QueryOptionsFunc{WithIndexedArrays()},
+			want:  "x",
+		},
+		{
+			name:      "Invalid indexes",
+			query:     "items[a]=a&items[-1]=b",
+			opts:      []QueryOptionsFunc{WithIndexedArrays()},
+			notExists: true,
+		},
+		{
+			name:    "Max index",
+			query:   "items[3]=d&items[4]=e",
+			opts:    []QueryOptionsFunc{WithIndexedArrays(), WithIndexedArraysMaxIndex(3)},
+			want:    []string{"", "", "", "d"},
+			ignored: true,
+		},
+		{
+			name:      "Huge index",
+			query:     "items[50000000]=a",
+			opts:      []QueryOptionsFunc{WithIndexedArrays()},
+			notExists: true,
+		},
+		{
+			name:      "Max int index",
+			query:     "items[9223372036854775807]=a",
+			opts:      []QueryOptionsFunc{WithIndexedArrays()},
+			notExists: true,
+		},
+		{
+			name:    "Huge index among valid ones",
+			query:   "items[0]=a&items[50000000]=b",
+			opts:    []QueryOptionsFunc{WithIndexedArrays()},
+			want:    []string{"a"},
+			ignored: true,
+		},
+		{
+			name:      "Disabled",
+			query:     "items[0]=a&items[2]=c",
+			notExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, requestURL+"?"+tt.query, nil)
+			require.NoError(t, err)
+
+			cache := make(Cache)
+
+			value, exists := NewQuery(tt.opts...).Parse(req, tag, cache)
+			if tt.notExists {
+				require.False(t, exists)
+				return
+			}
+
+			require.True(t, exists)
+			require.Equal(t, tt.want, value)
+
+			if _, ok := tt.want.([]string); ok {
+				_, exists = NewQuery(tt.opts...).Parse(req, `query:"*"`, cache)
+				require.Equal(t, tt.ignored, exists, "indexed keys are consumed, ignored keys are left")
+			}
+		})
+	}
+}
+
+func TestQuery_SuffixIndexedArrays(t *testing.T) {
+	tag := reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagQuery, "tag"))
+
+	tests := []struct {
+		name      string
+		query     string
+		opts      []QueryOptionsFunc
+		want      any
+		notExists bool
+	}{
+		{
+			name:  "Ordered suffixes",
+			query: "tag.1=go&tag.2=web",
+			opts:  []QueryOptionsFunc{WithSuffixIndexedArrays()},
+			want:  []string{"go", "web"},
+		},
+		{
+			name:  "Out of order suffixes",
+			query: "tag.10=c&tag.2=b&tag.1=a",
+			opts:  []QueryOptionsFunc{WithSuffixIndexedArrays()},
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name:  "Gaps are skipped",
+			query: "tag.1=a&tag.5=b&tag.0=z",
+			opts:  []QueryOptionsFunc{WithSuffixIndexedArrays()},
+			want:  []string{"z", "a", "b"},
+		},
+		{
+			name:  "Dotted name",
+			query: "tag.1=a&tag.name.1=b",
+			opts:  []QueryOptionsFunc{WithSuffixIndexedArrays()},
+			want:  []string{"a"},
+		},
+		{
+			name:  "Key without suffix takes precedence",
+			query: "tag=x&tag.1=a",
+			opts:  []QueryOptionsFunc{WithSuffixIndexedArrays()},
+			want:  "x",
+		},
+		{
+			name:  "Bracket indexes take precedence",
+			query: "tag[0]=x&tag.1=a",
+			opts:  []QueryOptionsFunc{WithIndexedArrays(), WithSuffixIndexedArrays()},
+			want:  []string{"x"},
+		},
+		{
+			name:      "Invalid suffixes",
+			query:     "tag.a=a&tag.-1=b&tag.=c",
+			opts:      []QueryOptionsFunc{WithSuffixIndexedArrays()},
+			notExists: true,
+		},
+		{
+			name:      "Disabled",
+			query:     "tag.1=go&tag.2=web",
+			notExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, requestURL+"?"+tt.query, nil)
+			require.NoError(t, err)
+
+			cache := make(Cache)
+
+			value, exists := NewQuery(tt.opts...).Parse(req, tag, cache)
+			if tt.notExists {
+				require.False(t, exists)
+				return
+			}
+
+			require.True(t, exists)
+			require.Equal(t, tt.want, value)
+		})
+	}
+
+	req, err := http.NewRequest(http.MethodGet, requestURL+"?tag.2=b&tag.1=a&page=1", nil)
+	require.NoError(t, err)
+
+	cache := make(Cache)
+	q := NewQuery(WithSuffixIndexedArrays())
+
+	_, exists := q.Parse(req, tag, cache)
+	require.True(t, exists)
+
+	rest, exists := q.Parse(req, `query:"*"`, cache)
+	require.True(t, exists)
+	require.Equal(t, url.Values{"page": {"1"}}, rest, "suffixed keys are consumed")
+}
+
+func TestQuery_DottedMaps(t *testing.T) {
+	tag := reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagQuery, "labels"))
+
+	tests := []struct {
+		name      string
+		query     string
+		opts      []QueryOptionsFunc
+		want      any
+		notExists bool
+	}{
+		{
+			name:  "One level",
+			query: "labels.env=prod&labels.team=core",
+			opts:  []QueryOptionsFunc{WithDottedMaps()},
+			want:  map[string]any{"env": []string{"prod"}, "team": []string{"core"}},
+		},
+		{
+			name:  "Two levels",
+			query: "labels.env.region=us&labels.env.zone=a&labels.team.name=core",
+			opts:  []QueryOptionsFunc{WithDottedMaps()},
+			want: map[string]any{
+				"env":  map[string]any{"region": []string{"us"}, "zone": []string{"a"}},
+				"team": map[string]any{"name": []string{"core"}},
+			},
+		},
+		{
+			name:  "Three levels",
+			query: "labels.env.region.zone=a&labels.env.region.zone=b",
+			opts:  []QueryOptionsFunc{WithDottedMaps()},
+			want: map[string]any{
+				"env": map[string]any{"region": map[string]any{"zone": []string{"a", "b"}}},
+			},
+		},
+		{
+			name:  "Max depth",
+			query: "labels.env.region.zone=a",
+			opts:  []QueryOptionsFunc{WithDottedMaps(), WithDottedMapsMaxDepth(2)},
+			want:  map[string]any{"env": map[string]any{"region.zone": []string{"a"}}},
+		},
+		{
+			name:  "Nested keys replace value",
+			query: "labels.env=x&labels.env.region=us",
+			opts:  []QueryOptionsFunc{WithDottedMaps()},
+			want:  map[string]any{"env": map[string]any{"region": []string{"us"}}},
+		},
+		{
+			name:  "Key without dots takes precedence",
+			query: "labels=x&labels.env=prod",
+			opts:  []QueryOptionsFunc{WithDottedMaps()},
+			want:  "x",
+		},
+		{
+			name:      "Empty path",
+			query:     "labels.=x&labelsenv=y",
+			opts:      []QueryOptionsFunc{WithDottedMaps()},
+			notExists: true,
+		},
+		{
+			name:      "Disabled",
+			query:     "labels.env=prod",
+			notExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, requestURL+"?"+tt.query, nil)
+			require.NoError(t, err)
+
+			value, exists := NewQuery(tt.opts...).Parse(req, tag, make(Cache))
+			if tt.notExists {
+				require.False(t, exists)
+				return
+			}
+
+			require.True(t, exists)
+			require.Equal(t, tt.want, value)
+		})
+	}
+
+	req, err := http.NewRequest(http.MethodGet, requestURL+"?labels.env.region=us&page=1", nil)
+	require.NoError(t, err)
+
+	cache := make(Cache)
+	q := NewQuery(WithDottedMaps())
+
+	_, exists := q.Parse(req, tag, cache)
+	require.True(t, exists)
+
+	rest, exists := q.Parse(req, `query:"*"`, cache)
+	require.True(t, exists)
+	require.Equal(t, url.Values{"page": {"1"}}, rest, "dotted keys are consumed")
+}
+
+func TestQuery_AutoSplit(t *testing.T) {
+	tag := reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagQuery, "ids"))
+
+	tests := []struct {
+		name  string
+		query string
+		opts  []QueryOptionsFunc
+		want  any
+	}{
+		{
+			name:  "Pipe",
+			query: "ids=1|2|3",
+			opts:  []QueryOptionsFunc{WithAutoSplit('|', ',', ' ')},
+			want:  []string{"1", "2", "3"},
+		},
+		{
+			name:  "Comma",
+			query: "ids=1,2,3",
+			opts:  []QueryOptionsFunc{WithAutoSplit('|', ',', ' ')},
+			want:  []string{"1", "2", "3"},
+		},
+		{
+			name:  "Space",
+			query: "ids=1+2+3",
+			opts:  []QueryOptionsFunc{WithAutoSplit('|', ',', ' ')},
+			want:  []string{"1", "2", "3"},
+		},
+		{
+			name:  "Earliest delimiter wins",
+			query: "ids=a|b,c",
+			opts:  []QueryOptionsFunc{WithAutoSplit('|', ',')},
+			want:  []string{"a", "b,c"},
+		},
+		{
+			name:  "Order of delimiters",
+			query: "ids=a|b,c",
+			opts:  []QueryOptionsFunc{WithAutoSplit(',', '|')},
+			want:  []string{"a|b", "c"},
+		},
+		{
+			name:  "No delimiter",
+			query: "ids=1",
+			opts:  []QueryOptionsFunc{WithAutoSplit('|', ',')},
+			want:  "1",
+		},
+		{
+			name:  "Not configured delimiter",
+			query: "ids=1.2",
+			opts:  []QueryOptionsFunc{WithAutoSplit('|', ',')},
+			want:  "1.2",
+		},
+		{
+			name:  "Without auto split",
+			query: "ids=1|2",
+			want:  "1|2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, requestURL+"?"+tt.query, nil)
+			require.NoError(t, err)
+
+			value, exists := NewQuery(tt.opts...).Parse(req, tag, make(Cache))
+			require.True(t, exists)
+			require.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestQuery_DecodePlus(t *testing.T) {
+	tag := reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagQuery, "token"))
+
+	tests := []struct {
+		name   string
+		query  string
+		opts   []QueryOptionsFunc
+		want   any
+		exists bool
+	}{
+		{
+			name:   "Base64 with decoded plus",
+			query:  "token=a+b/c==",
+			want:   "a b/c==",
+			exists: true,
+		},
+		{
+			name:   "Base64 with kept plus",
+			query:  "token=a+b/c==",
+			opts:   []QueryOptionsFunc{WithDecodePlus(false)},
+			want:   "a+b/c==",
+			exists: true,
+		},
+		{
+			name:   "Escaped space with kept plus",
+			query:  "token=a%20b+c",
+			opts:   []QueryOptionsFunc{WithDecodePlus(false)},
+			want:   "a b+c",
+			exists: true,
+		},
+		{
+			name:   "Escaped plus with kept plus",
+			query:  "token=a%2Bb",
+			opts:   []QueryOptionsFunc{WithDecodePlus(false)},
+			want:   "a+b",
+			exists: true,
+		},
+		{
+			name:   "Many values with kept plus",
+			query:  "other=1&token=a+b&token=c+d",
+			opts:   []QueryOptionsFunc{WithDecodePlus(false)},
+			want:   []string{"a+b", "c+d"},
+			exists: true,
+		},
+		{
+			name:  "Invalid escape with kept plus",
+			query: "token=a%zz",
+			opts:  []QueryOptionsFunc{WithDecodePlus(false)},
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, requestURL+"?"+tt.query, nil)
+			require.NoError(t, err)
+
+			value, exists := NewQuery(tt.opts...).Parse(req, tag, make(Cache))
+			require.Equal(t, tt.exists, exists)
+			require.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestQuery_DuplicatePolicy(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, requestURL+"?id=1&id=2&id=3", nil)
+	require.NoError(t, err)
+
+	tag := reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagQuery, "id"))
+
+	tests := []struct {
+		name   string
+		policy DuplicatePolicy
+		want   any
+	}{
+		{
+			name: "No policy",
+			want: []string{"1", "2", "3"},
+		},
+		{
+			name:   "First",
+			policy: DuplicateFirst,
+			want:   SplitValue{Raw: "1", Values: []string{"1", "2", "3"}},
+		},
+		{
+			name:   "Last",
+			policy: DuplicateLast,
+			want:   SplitValue{Raw: "3", Values: []string{"1", "2", "3"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, exists := NewQuery(WithDuplicatePolicy(tt.policy)).Parse(req, tag, make(Cache))
+			require.True(t, exists)
+			require.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestQuery_Consume(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, requestURL+"?id=1&items[0]=a&page=2", nil)
+	require.NoError(t, err)
+
+	q := NewQuery(WithIndexedArrays())
+	cache := make(Cache)
+
+	q.Consume(req, `query:"id"`, cache)
+	q.Consume(req, `query:"items"`, cache)
+	q.Consume(req, `query:"*"`, cache)
+	q.Consume(req, `header:"page"`, cache)
+
+	rest, exists := q.Parse(req, `query:"*"`, cache)
+	require.True(t, exists)
+	require.Equal(t, url.Values{"page": {"2"}}, rest)
 }
 
 func TestQuery(t *testing.T) {
@@ -170,6 +663,82 @@ func TestQuery(t *testing.T) {
 			},
 			want: []string{queryValue, queryValue},
 		},
+		{
+			name: "Get all values from query",
+			args: func() args {
+				rawURL, err := url.Parse(fmt.Sprintf("%s", requestURL))
+				require.NoError(t, err)
+
+				q := rawURL.Query()
+				q.Add(queryName, queryValue)
+				q.Add("filter[name]", "test")
+
+				rawURL.RawQuery = q.Encode()
+
+				req, err := http.NewRequest(http.MethodPost, rawURL.String(), nil)
+				require.NoError(t, err)
+
+				return args{
+					req:   req,
+					tag:   reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagQuery, TagValueAll)),
+					cache: make(Cache),
+				}
+			},
+			want: url.Values{queryName: {queryValue}, "filter[name]": {"test"}},
+		},
+		{
+			name: "Get all not consumed values from query",
+			args: func() args {
+				rawURL, err := url.Parse(fmt.Sprintf("%s", requestURL))
+				require.NoError(t, err)
+
+				q := rawURL.Query()
+				q.Add(queryName, queryValue)
+				q.Add("filter[name]", "test")
+
+				rawURL.RawQuery = q.Encode()
+
+				req, err := http.NewRequest(http.MethodPost, rawURL.String(), nil)
+				require.NoError(t, err)
+
+				cache := make(Cache)
+				_, ok := NewQuery().Parse(req, reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagQuery, queryName)), cache)
+				require.True(t, ok)
+
+				return args{
+					req:   req,
+					tag:   reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagQuery, TagValueAll)),
+					cache: cache,
+				}
+			},
+			want: url.Values{"filter[name]": {"test"}},
+		},
+		{
+			name:      "Get all values from query - everything consumed",
+			notExists: true,
+			args: func() args {
+				rawURL, err := url.Parse(fmt.Sprintf("%s", requestURL))
+				require.NoError(t, err)
+
+				q := rawURL.Query()
+				q.Add(queryName, queryValue)
+
+				rawURL.RawQuery = q.Encode()
+
+				req, err := http.NewRequest(http.MethodPost, rawURL.String(), nil)
+				require.NoError(t, err)
+
+				cache := make(Cache)
+				_, ok := NewQuery().Parse(req, reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagQuery, queryName)), cache)
+				require.True(t, ok)
+
+				return args{
+					req:   req,
+					tag:   reflect.StructTag(fmt.Sprintf(`%s:"%s"`, TagQuery, TagValueAll)),
+					cache: cache,
+				}
+			},
+		},
 		{
 			name:      "Wrong tag",
 			notExists: true,
@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+const (
+	// TagSort sort tag.
+	TagSort = "sort"
+	// SortAsc ascending sort order.
+	SortAsc = "asc"
+	// SortDesc descending sort order.
+	SortDesc = "desc"
+
+	sortFieldSeparator = ","
+	sortOrderSeparator = ":"
+)
+
+// SortSpec sort by a field.
+type SortSpec struct {
+	Field string
+	Order string
+}
+
+// SortOptionsFunc function for setting sort options.
+type SortOptionsFunc func(*Sort)
+
+// WithSortFields sets fields allowed for sorting, other fields are rejected with rerr.NotAllowed error.
+func WithSortFields(fields ...string) SortOptionsFunc {
+	return func(s *Sort) {
+		if s.allowed == nil {
+			s.allowed = make(map[string]struct{}, len(fields))
+		}
+
+		for _, f := range fields {
+			s.allowed[f] = struct{}{}
+		}
+	}
+}
+
+// Sort is a parser of multi-field sort specs from query into []SortSpec,
+// e.g. `?sort=name,-created_at` or `?sort=name:asc,created_at:desc`.
+//
+// Tag value is a query parameter, e.g. `sort:"order_by"`, empty tag value `sort:""` uses parameter of parser.
+type Sort struct {
+	param   string
+	allowed map[string]struct{}
+}
+
+// NewSort returns new sort parser reading query parameter by default.
+func NewSort(param string, opts ...SortOptionsFunc) *Sort {
+	s := Sort{param: param}
+
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	return &s
+}
+
+// Parse parses sort specs, invalid specs are not returned.
+func (s *Sort) Parse(r *http.Request, tag reflect.StructTag, cache Cache) (any, bool) {
+	v, ok, err := s.ParseWithError(r, tag, cache)
+	if err != nil {
+		return nil, false
+	}
+
+	return v, ok
+}
+
+// ParseWithError parses sort specs, rejects fields which are not allowed and unknown orders.
+func (s *Sort) ParseWithError(r *http.Request, tag reflect.StructTag, _ Cache) (any, bool, error) {
+	tagValue, ok := tag.Lookup(TagSort)
+	if !ok {
+		return nil, false, nil
+	}
+
+	param, _ := SplitTagValue(tagValue)
+	if len(param) == 0 {
+		param = s.param
+	}
+
+	values, ok := r.URL.Query()[param]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var specs []SortSpec
+	for _, value := range values {
+		for _, spec := range strings.Split(value, sortFieldSeparator) {
+			spec = strings.TrimSpace(spec)
+			if len(spec) == 0 {
+				continue
+			}
+
+			parsed, err := s.parseSpec(spec)
+			if err != nil {
+				return nil, false, err
+			}
+
+			specs = append(specs, parsed)
+		}
+	}
+
+	if len(specs) == 0 {
+		return nil, false, nil
+	}
+
+	return specs, true, nil
+}
+
+// Tag returns working tag.
+func (s *Sort) Tag() string {
+	return TagSort
+}
+
+// parseSpec parses single sort spec: `field`, `-field`, `+field` or `field:order`.
+func (s *Sort) parseSpec(spec string) (SortSpec, error) {
+	sortSpec := SortSpec{Field: spec, Order: SortAsc}
+
+	switch {
+	case strings.HasPrefix(spec, "-"):
+		sortSpec = SortSpec{Field: spec[1:], Order: SortDesc}
+	case strings.HasPrefix(spec, "+"):
+		sortSpec.Field = spec[1:]
+	default:
+		if field, order, found := strings.Cut(spec, sortOrderSeparator); found {
+			order = strings.ToLower(order)
+			if order != SortAsc && order != SortDesc {
+				return SortSpec{}, errors.Wrapf(rerr.NotAllowed, "sort order `%s`", order)
+			}
+
+			sortSpec = SortSpec{Field: field, Order: order}
+		}
+	}
+
+	if s.allowed != nil {
+		if _, ok := s.allowed[sortSpec.Field]; !ok {
+			return SortSpec{}, errors.Wrapf(rerr.NotAllowed, "sort field `%s`", sortSpec.Field)
+		}
+	}
+
+	return sortSpec, nil
+}
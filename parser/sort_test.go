@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSort(t *testing.T) {
+	s := NewSort("sort")
+	require.NotNil(t, s)
+	require.Equal(t, TagSort, s.Tag())
+	require.Equal(t, "sort", s.param)
+	require.Nil(t, s.allowed)
+
+	s = NewSort("sort", WithSortFields("name"))
+	require.Contains(t, s.allowed, "name")
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		tag       reflect.StructTag
+		opts      []SortOptionsFunc
+		want      []SortSpec
+		wantErr   error
+		notExists bool
+	}{
+		{
+			name:  "Prefixes",
+			query: "sort=name,-created_at,+id",
+			tag:   `sort:""`,
+			want: []SortSpec{
+				{Field: "name", Order: SortAsc},
+				{Field: "created_at", Order: SortDesc},
+				{Field: "id", Order: SortAsc},
+			},
+		},
+		{
+			name:  "Explicit orders from repeated params",
+			query: "order_by=name:DESC&order_by=id:asc",
+			tag:   `sort:"order_by"`,
+			want: []SortSpec{
+				{Field: "name", Order: SortDesc},
+				{Field: "id", Order: SortAsc},
+			},
+		},
+		{
+			name:  "Allowed fields",
+			query: "sort=-name",
+			tag:   `sort:""`,
+			opts:  []SortOptionsFunc{WithSortFields("name", "created_at")},
+			want:  []SortSpec{{Field: "name", Order: SortDesc}},
+		},
+		{
+			name:    "Disallowed field",
+			query:   "sort=name,-password",
+			tag:     `sort:""`,
+			opts:    []SortOptionsFunc{WithSortFields("name", "created_at")},
+			wantErr: rerr.NotAllowed,
+		},
+		{
+			name:    "Unknown order",
+			query:   "sort=name:up",
+			tag:     `sort:""`,
+			wantErr: rerr.NotAllowed,
+		},
+		{
+			name:      "No param",
+			query:     "page=1",
+			tag:       `sort:""`,
+			notExists: true,
+		},
+		{
+			name:      "Wrong tag",
+			query:     "sort=name",
+			tag:       `query:"sort"`,
+			notExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, requestURL+"?"+tt.query, nil)
+			require.NoError(t, err)
+
+			s := NewSort("sort", tt.opts...)
+
+			v, exists, err := s.ParseWithError(req, tt.tag, make(Cache))
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				_, exists = s.Parse(req, tt.tag, make(Cache))
+				require.False(t, exists)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.notExists {
+				require.False(t, exists)
+				return
+			}
+
+			require.True(t, exists)
+			require.Equal(t, tt.want, v)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package parser
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+const (
+	// TagOptionMaxItems tag option limiting amount of array elements, e.g. `query:"ids,maxitems=100"`.
+	TagOptionMaxItems = "maxitems"
+	// TagOptionSkipEmpty tag option ignoring present but empty values, e.g. `query:"name,skipempty"`.
+	TagOptionSkipEmpty = "skipempty"
+	// TagOptionKeyCase tag option converting case of map keys, e.g. `query:"*,keycase=lower"`.
+	TagOptionKeyCase = "keycase"
+
+	// TagOptionNull tag option treating a literal as absent value, so pointer fields stay nil,
+	// e.g. `query:"middle_name,null"` or with custom literal `query:"middle_name,null=none"`.
+	TagOptionNull = "null"
+
+	// TagOptionFlag tag option binding boolean field from presence of a key, e.g. `query:"verbose,flag"`,
+	// present key with empty value is true, absent key is false.
+	TagOptionFlag = "flag"
+	// TagOptionInvert tag option negating boolean value, e.g. `query:"no_cache,flag,invert"`.
+	TagOptionInvert = "invert"
+
+	// TagOptionBase64JSON tag option decoding base64url value and unmarshalling json result into a field,
+	// e.g. `query:"state,base64json"`.
+	TagOptionBase64JSON = "base64json"
+
+	// TagOptionOverride tag option making value of parser take precedence over value decoded from body,
+	// e.g. `query:"limit,override" json:"limit"`.
+	TagOptionOverride = "override"
+
+	// TagOptionStrict tag option rejecting unknown keys of key-value string set into a struct field,
+	// e.g. `query:"opts,strict"` with `?opts=color=red,size=10`.
+	TagOptionStrict = "strict"
+
+	// DefaultNullLiteral default literal of null option.
+	DefaultNullLiteral = "null"
+
+	// KeyCaseLower lower case of map keys.
+	KeyCaseLower = "lower"
+	// KeyCaseUpper upper case of map keys.
+	KeyCaseUpper = "upper"
+
+	tagOptionSeparator      = ","
+	tagOptionValueSeparator = "="
+)
+
+// knownTagOptions options which can follow a name in tag value.
+var knownTagOptions = map[string]struct{}{
+	TagOptionMaxItems:   {},
+	TagOptionSkipEmpty:  {},
+	TagOptionKeyCase:    {},
+	TagOptionNull:       {},
+	TagOptionFlag:       {},
+	TagOptionInvert:     {},
+	TagOptionBase64JSON: {},
+	TagOptionOverride:   {},
+	TagOptionStrict:     {},
+}
+
+// TagOptions options of struct tag value.
+type TagOptions map[string]string
+
+// Has reports whether option is present.
+func (o TagOptions) Has(name string) bool {
+	_, ok := o[name]
+	return ok
+}
+
+// Get returns value of option.
+func (o TagOptions) Get(name string) (string, bool) {
+	v, ok := o[name]
+	return v, ok
+}
+
+// Int returns value of option as integer.
+func (o TagOptions) Int(name string) (int, bool, error) {
+	v, ok := o[name]
+	if !ok {
+		return 0, false, nil
+	}
+
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false, errors.Wrapf(rerr.InvalidTag, "option `%s=%s`", name, v)
+	}
+
+	return i, true, nil
+}
+
+// SkipValue reports whether parsed value must be ignored according to options.
+func (o TagOptions) SkipValue(v any) bool {
+	if !o.Has(TagOptionSkipEmpty) {
+		return false
+	}
+
+	switch t := v.(type) {
+	case string:
+		return len(t) == 0
+	case []string:
+		return len(t) == 0 || (len(t) == 1 && len(t[0]) == 0)
+	case SplitValue:
+		return len(t.Raw) == 0
+	}
+
+	return false
+}
+
+// IsNull reports whether parsed value is a null literal according to options.
+func (o TagOptions) IsNull(v any) bool {
+	literal, ok := o.Get(TagOptionNull)
+	if !ok {
+		return false
+	}
+
+	if len(literal) == 0 {
+		literal = DefaultNullLiteral
+	}
+
+	switch t := v.(type) {
+	case string:
+		return t == literal
+	case []string:
+		return len(t) == 1 && t[0] == literal
+	case SplitValue:
+		return t.Raw == literal
+	}
+
+	return false
+}
+
+// IsFlag reports whether options bind a boolean flag.
+func (o TagOptions) IsFlag() bool {
+	return o.Has(TagOptionFlag) || o.Has(TagOptionInvert)
+}
+
+// FlagValue returns boolean value of a flag according to options, v is ignored if key is not present.
+//
+// With flag option empty value of present key is true and absent key is false,
+// otherwise value is parsed by strconv.ParseBool. Invert option negates the result.
+func (o TagOptions) FlagValue(v any, present bool) (bool, error) {
+	var str string
+	switch t := v.(type) {
+	case string:
+		str = t
+	case []string:
+		if len(t) > 0 {
+			str = t[0]
+		}
+	case SplitValue:
+		str = t.Raw
+	}
+
+	var flag bool
+	switch {
+	case !present:
+	case len(str) == 0 && o.Has(TagOptionFlag):
+		flag = true
+	default:
+		parsed, err := strconv.ParseBool(str)
+		if err != nil {
+			return false, errors.Wrapf(rerr.InvalidFormat, "flag `%s`", str)
+		}
+
+		flag = parsed
+	}
+
+	if o.Has(TagOptionInvert) {
+		flag = !flag
+	}
+
+	return flag, nil
+}
+
+// ConvertKeys converts case of map keys according to options,
+// values of keys which become equal after conversion are merged.
+func (o TagOptions) ConvertKeys(v any) (any, error) {
+	keyCase, ok := o.Get(TagOptionKeyCase)
+	if !ok {
+		return v, nil
+	}
+
+	var convert func(string) string
+	switch keyCase {
+	case KeyCaseLower:
+		convert = strings.ToLower
+	case KeyCaseUpper:
+		convert = strings.ToUpper
+	default:
+		return nil, errors.Wrapf(rerr.InvalidTag, "option `%s=%s`", TagOptionKeyCase, keyCase)
+	}
+
+	var m map[string][]string
+	switch t := v.(type) {
+	case url.Values:
+		m = t
+	case map[string][]string:
+		m = t
+	default:
+		return v, nil
+	}
+
+	converted := make(url.Values, len(m))
+	for k, values := range m {
+		k = convert(k)
+		converted[k] = append(converted[k], values...)
+	}
+
+	return converted, nil
+}
+
+// SplitTagValue splits tag value into name and options.
+//
+// Only known options are split off, other comma-separated parts stay in the name,
+// so `header:"Referer,X-Referer"` keeps both header names.
+func SplitTagValue(tagValue string) (string, TagOptions) {
+	if !strings.Contains(tagValue, tagOptionSeparator) {
+		return tagValue, nil
+	}
+
+	parts := strings.Split(tagValue, tagOptionSeparator)
+	names := parts[:1]
+
+	var opts TagOptions
+	for _, part := range parts[1:] {
+		key, v, _ := strings.Cut(strings.TrimSpace(part), tagOptionValueSeparator)
+		if _, ok := knownTagOptions[key]; !ok {
+			names = append(names, part)
+			continue
+		}
+
+		if opts == nil {
+			opts = make(TagOptions, len(parts)-1)
+		}
+
+		opts[key] = v
+	}
+
+	if opts == nil {
+		return tagValue, nil
+	}
+
+	return strings.Join(names, tagOptionSeparator), opts
+}
+
+// CheckMaxItems checks that amount of array elements does not exceed max items option.
+func CheckMaxItems(opts TagOptions, amount int) error {
+	maxItems, ok, err := opts.Int(TagOptionMaxItems)
+	if err != nil || !ok {
+		return err
+	}
+
+	if amount > maxItems {
+		return errors.Wrapf(rerr.TooManyItems, "%d items exceed limit of %d", amount, maxItems)
+	}
+
+	return nil
+}
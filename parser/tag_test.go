@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"net/url"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTagValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		tagValue string
+		wantName string
+		wantOpts TagOptions
+	}{
+		{
+			name:     "Name only",
+			tagValue: "ids",
+			wantName: "ids",
+		},
+		{
+			name:     "Name with option",
+			tagValue: "ids,maxitems=100",
+			wantName: "ids",
+			wantOpts: TagOptions{TagOptionMaxItems: "100"},
+		},
+		{
+			name:     "Name with many options",
+			tagValue: "name,skipempty,maxitems=1",
+			wantName: "name",
+			wantOpts: TagOptions{TagOptionSkipEmpty: "", TagOptionMaxItems: "1"},
+		},
+		{
+			name:     "Many names",
+			tagValue: "Referer,X-Referer",
+			wantName: "Referer,X-Referer",
+		},
+		{
+			name:     "Many names with option",
+			tagValue: "Referer,X-Referer,maxitems=1",
+			wantName: "Referer,X-Referer",
+			wantOpts: TagOptions{TagOptionMaxItems: "1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, opts := SplitTagValue(tt.tagValue)
+			require.Equal(t, tt.wantName, name)
+			require.Equal(t, tt.wantOpts, opts)
+		})
+	}
+}
+
+func TestCheckMaxItems(t *testing.T) {
+	require.NoError(t, CheckMaxItems(nil, 1000))
+	require.NoError(t, CheckMaxItems(TagOptions{TagOptionMaxItems: "2"}, 2))
+	require.ErrorIs(t, CheckMaxItems(TagOptions{TagOptionMaxItems: "2"}, 3), rerr.TooManyItems)
+	require.ErrorIs(t, CheckMaxItems(TagOptions{TagOptionMaxItems: "two"}, 1), rerr.InvalidTag)
+}
+
+func TestTagOptions_SkipValue(t *testing.T) {
+	skipEmpty := TagOptions{TagOptionSkipEmpty: ""}
+
+	require.True(t, skipEmpty.SkipValue(""))
+	require.True(t, skipEmpty.SkipValue([]string{""}))
+	require.True(t, skipEmpty.SkipValue([]string{}))
+	require.True(t, skipEmpty.SkipValue(SplitValue{}))
+	require.False(t, skipEmpty.SkipValue("value"))
+	require.False(t, skipEmpty.SkipValue([]string{"", ""}))
+	require.False(t, skipEmpty.SkipValue(1))
+
+	require.False(t, TagOptions(nil).SkipValue(""))
+}
+
+func TestTagOptions_IsNull(t *testing.T) {
+	null := TagOptions{TagOptionNull: ""}
+
+	require.True(t, null.IsNull("null"))
+	require.True(t, null.IsNull([]string{"null"}))
+	require.True(t, null.IsNull(SplitValue{Raw: "null", Values: []string{"null"}}))
+	require.False(t, null.IsNull("value"))
+	require.False(t, null.IsNull([]string{"null", "null"}))
+	require.False(t, null.IsNull(1))
+
+	custom := TagOptions{TagOptionNull: "none"}
+	require.True(t, custom.IsNull("none"))
+	require.False(t, custom.IsNull("null"))
+
+	require.False(t, TagOptions(nil).IsNull("null"))
+}
+
+func TestTagOptions_FlagValue(t *testing.T) {
+	flag := TagOptions{TagOptionFlag: ""}
+	inverted := TagOptions{TagOptionFlag: "", TagOptionInvert: ""}
+
+	tests := []struct {
+		name    string
+		opts    TagOptions
+		v       any
+		present bool
+		want    bool
+		wantErr error
+	}{
+		{name: "present", opts: flag, v: "", present: true, want: true},
+		{name: "absent", opts: flag, want: false},
+		{name: "present with value", opts: flag, v: "false", present: true, want: false},
+		{name: "present of duplicate keys", opts: flag, v: []string{"", ""}, present: true, want: true},
+		{name: "inverted present", opts: inverted, v: "", present: true, want: false},
+		{name: "inverted absent", opts: inverted, want: true},
+		{name: "inverted with value", opts: inverted, v: SplitValue{Raw: "false"}, present: true, want: true},
+		{name: "invert without flag", opts: TagOptions{TagOptionInvert: ""}, v: "true", present: true, want: false},
+		{name: "invert without flag of empty value", opts: TagOptions{TagOptionInvert: ""}, v: "", present: true, wantErr: rerr.InvalidFormat},
+		{name: "invalid value", opts: flag, v: "maybe", present: true, wantErr: rerr.InvalidFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.True(t, tt.opts.IsFlag())
+
+			got, err := tt.opts.FlagValue(tt.v, tt.present)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	require.False(t, TagOptions(nil).IsFlag())
+}
+
+func TestTagOptions_ConvertKeys(t *testing.T) {
+	values := url.Values{"Filter[Name]": {"a"}, "filter[name]": {"b"}, "SORT": {"c"}}
+
+	converted, err := TagOptions{TagOptionKeyCase: KeyCaseLower}.ConvertKeys(values)
+	require.NoError(t, err)
+	require.Len(t, converted, 2)
+	require.ElementsMatch(t, []string{"a", "b"}, converted.(url.Values)["filter[name]"])
+	require.Equal(t, []string{"c"}, converted.(url.Values)["sort"])
+
+	converted, err = TagOptions{TagOptionKeyCase: KeyCaseUpper}.ConvertKeys(map[string][]string{"sort": {"c"}})
+	require.NoError(t, err)
+	require.Equal(t, url.Values{"SORT": {"c"}}, converted)
+
+	converted, err = TagOptions{}.ConvertKeys(values)
+	require.NoError(t, err)
+	require.Equal(t, values, converted)
+
+	converted, err = TagOptions{TagOptionKeyCase: KeyCaseLower}.ConvertKeys("Value")
+	require.NoError(t, err)
+	require.Equal(t, "Value", converted)
+
+	_, err = TagOptions{TagOptionKeyCase: "title"}.ConvertKeys(values)
+	require.ErrorIs(t, err, rerr.InvalidTag)
+}
@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	// TagTraceparent traceparent tag, value is a name of trace context field, e.g. `traceparent:"trace_id"`.
+	TagTraceparent = "traceparent"
+	// HeaderTraceparent W3C trace context header.
+	HeaderTraceparent = "traceparent"
+	// TraceparentVersion version field of traceparent header.
+	TraceparentVersion = "version"
+	// TraceparentTraceID trace id field of traceparent header.
+	TraceparentTraceID = "trace_id"
+	// TraceparentSpanID span id field of traceparent header, also known as parent id.
+	TraceparentSpanID = "span_id"
+	// TraceparentParentID alias of span id field.
+	TraceparentParentID = "parent_id"
+	// TraceparentFlags trace flags field of traceparent header.
+	TraceparentFlags    = "flags"
+	cacheKeyTraceparent = "traceparent"
+
+	traceparentLength = 55
+)
+
+// Traceparent is a parser of W3C traceparent header,
+// e.g. `00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01` fills `traceparent:"trace_id"` field
+// with `4bf92f3577b34da6a3ce929d0e0e4736`.
+//
+// Header is validated as a whole, malformed header makes all fields absent,
+// tag value `*` returns all fields as map[string]string.
+type Traceparent struct{}
+
+// NewTraceparent returns new W3C traceparent parser.
+func NewTraceparent() *Traceparent {
+	return &Traceparent{}
+}
+
+// Parse parses trace context field from request.
+func (t *Traceparent) Parse(r *http.Request, tag reflect.StructTag, cache Cache) (any, bool) {
+	tagValue, ok := tag.Lookup(TagTraceparent)
+	if !ok {
+		return "", false
+	}
+
+	tagValue, _ = SplitTagValue(tagValue)
+
+	fields, ok := cache[cacheKeyTraceparent].(map[string]string)
+	if !ok {
+		fields = parseTraceparent(r.Header.Get(HeaderTraceparent))
+		cache[cacheKeyTraceparent] = fields
+	}
+
+	if len(fields) == 0 {
+		return "", false
+	}
+
+	if tagValue == TagValueAll {
+		return fields, true
+	}
+
+	if tagValue == TraceparentParentID {
+		tagValue = TraceparentSpanID
+	}
+
+	field, ok := fields[tagValue]
+	return field, ok
+}
+
+// Tag returns working tag.
+func (t *Traceparent) Tag() string {
+	return TagTraceparent
+}
+
+// parseTraceparent validates and splits traceparent header, returns nil if header is malformed.
+//
+// Headers of future versions may have additional fields after flags, they are ignored.
+func parseTraceparent(header string) map[string]string {
+	header = strings.TrimSpace(header)
+	if len(header) < traceparentLength {
+		return nil
+	}
+
+	version := header[:2]
+	if !isLowerHex(version) || version == "ff" {
+		return nil
+	}
+
+	if len(header) > traceparentLength && (version == "00" || header[traceparentLength] != '-') {
+		return nil
+	}
+
+	if header[2] != '-' || header[35] != '-' || header[52] != '-' {
+		return nil
+	}
+
+	traceID, spanID, flags := header[3:35], header[36:52], header[53:55]
+	if !isLowerHex(traceID) || isZeros(traceID) ||
+		!isLowerHex(spanID) || isZeros(spanID) ||
+		!isLowerHex(flags) {
+		return nil
+	}
+
+	return map[string]string{
+		TraceparentVersion: version,
+		TraceparentTraceID: traceID,
+		TraceparentSpanID:  spanID,
+		TraceparentFlags:   flags,
+	}
+}
+
+// isLowerHex reports whether s consists of lowercase hex digits.
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isZeros reports whether s consists of zeros.
+func isZeros(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
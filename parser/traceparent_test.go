@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testTraceparentHeader = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestNewTraceparent(t *testing.T) {
+	p := NewTraceparent()
+	require.NotNil(t, p)
+	require.Equal(t, TagTraceparent, p.Tag())
+}
+
+func TestTraceparent(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		tag       reflect.StructTag
+		want      any
+		notExists bool
+	}{
+		{
+			name:   "Trace id",
+			header: testTraceparentHeader,
+			tag:    `traceparent:"trace_id"`,
+			want:   "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name:   "Span id",
+			header: testTraceparentHeader,
+			tag:    `traceparent:"span_id"`,
+			want:   "00f067aa0ba902b7",
+		},
+		{
+			name:   "Parent id",
+			header: testTraceparentHeader,
+			tag:    `traceparent:"parent_id"`,
+			want:   "00f067aa0ba902b7",
+		},
+		{
+			name:   "Version",
+			header: testTraceparentHeader,
+			tag:    `traceparent:"version"`,
+			want:   "00",
+		},
+		{
+			name:   "Flags",
+			header: testTraceparentHeader,
+			tag:    `traceparent:"flags"`,
+			want:   "01",
+		},
+		{
+			name:   "All fields",
+			header: testTraceparentHeader,
+			tag:    `traceparent:"*"`,
+			want: map[string]string{
+				"version":  "00",
+				"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+				"span_id":  "00f067aa0ba902b7",
+				"flags":    "01",
+			},
+		},
+		{
+			name:   "Future version with additional fields",
+			header: "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			tag:    `traceparent:"trace_id"`,
+			want:   "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name:      "Unknown field",
+			header:    testTraceparentHeader,
+			tag:       `traceparent:"sampled"`,
+			notExists: true,
+		},
+		{
+			name:      "Malformed",
+			header:    "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+			tag:       `traceparent:"trace_id"`,
+			notExists: true,
+		},
+		{
+			name:      "Uppercase hex",
+			header:    "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+			tag:       `traceparent:"trace_id"`,
+			notExists: true,
+		},
+		{
+			name:      "Zero trace id",
+			header:    "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			tag:       `traceparent:"trace_id"`,
+			notExists: true,
+		},
+		{
+			name:      "Zero span id",
+			header:    "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+			tag:       `traceparent:"trace_id"`,
+			notExists: true,
+		},
+		{
+			name:      "Invalid version",
+			header:    "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			tag:       `traceparent:"trace_id"`,
+			notExists: true,
+		},
+		{
+			name:      "Version 00 with additional fields",
+			header:    testTraceparentHeader + "-extra",
+			tag:       `traceparent:"trace_id"`,
+			notExists: true,
+		},
+		{
+			name:      "Invalid delimiter",
+			header:    "00_4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7_01",
+			tag:       `traceparent:"trace_id"`,
+			notExists: true,
+		},
+		{
+			name:      "Missing header",
+			tag:       `traceparent:"trace_id"`,
+			notExists: true,
+		},
+		{
+			name:      "Missing tag",
+			header:    testTraceparentHeader,
+			tag:       `header:"traceparent"`,
+			notExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+			require.NoError(t, err)
+
+			if len(tt.header) > 0 {
+				req.Header.Set(HeaderTraceparent, tt.header)
+			}
+
+			got, exists := NewTraceparent().Parse(req, tt.tag, make(Cache))
+			if tt.notExists {
+				require.False(t, exists)
+				return
+			}
+
+			require.True(t, exists)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
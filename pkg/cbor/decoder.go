@@ -0,0 +1,71 @@
+// Package cbor cbor extensions.
+package cbor
+
+import (
+	"io"
+	"net/http"
+
+	fxcbor "github.com/fxamacker/cbor/v2"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ContentTypeCBOR content-type header for cbor decoder.
+	ContentTypeCBOR = "application/cbor"
+	// TagCBOR struct tag used by cbor decoder.
+	TagCBOR = "cbor"
+)
+
+// OptionsFunc function for setting options.
+type OptionsFunc func(*CBOR)
+
+// WithContentType sets content type.
+func WithContentType(contentType string) OptionsFunc {
+	return func(c *CBOR) {
+		c.contentType = contentType
+	}
+}
+
+// CBOR cbor decoder.
+type CBOR struct {
+	contentType string
+}
+
+// NewCBOR returns new cbor decoder.
+func NewCBOR(opts ...OptionsFunc) *CBOR {
+	c := CBOR{
+		contentType: ContentTypeCBOR,
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &c
+}
+
+// Decode decodes request body into ptr.
+func (c *CBOR) Decode(r *http.Request, ptr any) error {
+	if err := fxcbor.NewDecoder(r.Body).Decode(ptr); err != nil {
+		if !errors.Is(err, io.EOF) {
+			return errors.WithMessage(err, "malformed cbor")
+		}
+	}
+
+	return nil
+}
+
+// ContentType returns content-type header value.
+func (c *CBOR) ContentType() string {
+	return c.contentType
+}
+
+// Tag returns struct tag used by decoder.
+func (c *CBOR) Tag() string {
+	return TagCBOR
+}
+
+// Tags returns struct tags used by decoder.
+func (c *CBOR) Tags() []string {
+	return []string{TagCBOR}
+}
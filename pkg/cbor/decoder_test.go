@@ -0,0 +1,133 @@
+package cbor
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	fxcbor "github.com/fxamacker/cbor/v2"
+	"github.com/slipros/roamer"
+	"github.com/stretchr/testify/require"
+)
+
+const requestURL = "test.com"
+
+func toCBOR(t *testing.T, v any) *bytes.Buffer {
+	data, err := fxcbor.Marshal(v)
+	require.NoError(t, err, "unable convert `%T` to cbor", v)
+
+	return bytes.NewBuffer(data)
+}
+
+func TestNewCBOR(t *testing.T) {
+	c := NewCBOR()
+	require.NotNil(t, c)
+	require.Equal(t, ContentTypeCBOR, c.ContentType())
+	require.Equal(t, TagCBOR, c.Tag())
+	require.Equal(t, []string{TagCBOR}, c.Tags())
+
+	c = NewCBOR(WithContentType("test"))
+	require.NotNil(t, c)
+	require.Equal(t, "test", c.ContentType())
+}
+
+func TestCBOR_Decode(t *testing.T) {
+	type Location struct {
+		Lat float64 `cbor:"lat"`
+		Lng float64 `cbor:"lng"`
+	}
+
+	type Data struct {
+		DeviceID string   `cbor:"device_id"`
+		Readings []int    `cbor:"readings"`
+		Location Location `cbor:"location"`
+	}
+
+	type args struct {
+		req  *http.Request
+		ptr  any
+		want any
+	}
+	tests := []struct {
+		name    string
+		args    func() args
+		wantErr bool
+	}{
+		{
+			name: "Success fill struct with nested fields",
+			args: func() args {
+				data := Data{
+					DeviceID: "sensor-1",
+					Readings: []int{1, 2, 3},
+					Location: Location{Lat: 55.75, Lng: 37.61},
+				}
+
+				req, err := http.NewRequest(http.MethodPost, requestURL, toCBOR(t, &data))
+				require.NoError(t, err)
+
+				return args{
+					req:  req,
+					ptr:  &Data{},
+					want: &data,
+				}
+			},
+		},
+		{
+			name: "Empty body",
+			args: func() args {
+				req, err := http.NewRequest(http.MethodPost, requestURL, bytes.NewReader(nil))
+				require.NoError(t, err)
+
+				return args{
+					req:  req,
+					ptr:  &Data{},
+					want: &Data{},
+				}
+			},
+		},
+		{
+			name: "Error malformed body",
+			args: func() args {
+				req, err := http.NewRequest(http.MethodPost, requestURL, bytes.NewReader([]byte{0xbf, 0x61}))
+				require.NoError(t, err)
+
+				return args{
+					req: req,
+					ptr: &Data{},
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCBOR()
+			args := tt.args()
+
+			err := c.Decode(args.req, args.ptr)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, args.want, args.ptr)
+		})
+	}
+}
+
+func TestCBOR_Roamer(t *testing.T) {
+	type Data struct {
+		DeviceID string `cbor:"device_id"`
+	}
+
+	req, err := http.NewRequest(http.MethodPost, requestURL, toCBOR(t, map[string]string{"device_id": "sensor-1"}))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", ContentTypeCBOR)
+
+	var d Data
+	err = roamer.NewRoamer(roamer.WithDecoders(NewCBOR()), roamer.WithStrictTags()).Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{DeviceID: "sensor-1"}, d)
+}
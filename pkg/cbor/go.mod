@@ -0,0 +1,21 @@
+module github.com/slipros/roamer/pkg/cbor
+
+go 1.22.0
+
+require (
+	github.com/fxamacker/cbor/v2 v2.9.4
+	github.com/pkg/errors v0.9.1
+	github.com/slipros/roamer v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/slipros/exp v1.1.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	golang.org/x/exp v0.0.0-20250103183323-7d7fa50e5329 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/slipros/roamer => ../..
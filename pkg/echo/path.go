@@ -0,0 +1,39 @@
+// Package echo echo router extensions.
+package echo
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// contextKey context key of echo context.
+type contextKey struct{}
+
+// Middleware stores echo context into context of http request, so Path can read path parameters.
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		r := c.Request()
+		c.SetRequest(r.WithContext(context.WithValue(r.Context(), contextKey{}, c)))
+
+		return next(c)
+	}
+}
+
+// Path path parser for echo router.
+//
+// Echo context must be stored into context of http request by Middleware.
+func Path(r *http.Request, name string) (string, bool) {
+	c, ok := r.Context().Value(contextKey{}).(echo.Context)
+	if !ok {
+		return "", false
+	}
+
+	path := c.Param(name)
+	if len(path) == 0 {
+		return "", false
+	}
+
+	return path, true
+}
@@ -0,0 +1,85 @@
+package echo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		names  []string
+		values []string
+		param  string
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "param",
+			names:  []string{"user_id"},
+			values: []string{"42"},
+			param:  "user_id",
+			want:   "42",
+			wantOk: true,
+		},
+		{
+			name:   "missing param",
+			names:  []string{"user_id"},
+			values: []string{"42"},
+			param:  "post_id",
+		},
+		{
+			name:   "empty param",
+			names:  []string{"user_id"},
+			values: []string{""},
+			param:  "user_id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/user/42", nil)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+			c.SetParamNames(tt.names...)
+			c.SetParamValues(tt.values...)
+
+			var (
+				got string
+				ok  bool
+			)
+
+			h := Middleware(func(c echo.Context) error {
+				got, ok = Path(c.Request(), tt.param)
+				return nil
+			})
+
+			require.NoError(t, h(c))
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/user/42", nil)
+	_, ok := Path(req, "user_id")
+	require.False(t, ok, "without middleware")
+}
+
+func TestPath_Route(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware)
+
+	var got string
+	e.GET("/user/:user_id", func(c echo.Context) error {
+		got, _ = Path(c.Request(), "user_id")
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/user/42", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "42", got)
+}
@@ -0,0 +1,38 @@
+// Package gin gin router extensions.
+package gin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contextKey context key of gin path params.
+type contextKey struct{}
+
+// Middleware stores path params of gin context into context of http request, so Path can read them.
+//
+// Gin keeps path params in gin.Context instead of http request, so the middleware must run
+// before roamer parses the request, e.g. `router.Use(rgin.Middleware)`.
+func Middleware(c *gin.Context) {
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), contextKey{}, c.Params))
+	c.Next()
+}
+
+// Path path parser for gin router.
+//
+// Path params must be stored into context of http request by Middleware.
+func Path(r *http.Request, name string) (string, bool) {
+	params, ok := r.Context().Value(contextKey{}).(gin.Params)
+	if !ok {
+		return "", false
+	}
+
+	path, ok := params.Get(name)
+	if !ok || len(path) == 0 {
+		return "", false
+	}
+
+	return path, true
+}
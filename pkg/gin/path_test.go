@@ -0,0 +1,61 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		url    string
+		param  string
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "param",
+			url:    "/users/42",
+			param:  "id",
+			want:   "42",
+			wantOk: true,
+		},
+		{
+			name:  "missing param",
+			url:   "/users/42",
+			param: "user_id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Middleware)
+
+			var (
+				got string
+				ok  bool
+			)
+
+			router.GET("/users/:id", func(c *gin.Context) {
+				got, ok = Path(c.Request, tt.param)
+				c.Status(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, ok := Path(httptest.NewRequest(http.MethodGet, "/users/42", nil), "id")
+	require.False(t, ok, "without middleware")
+}
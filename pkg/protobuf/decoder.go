@@ -0,0 +1,87 @@
+// Package protobuf protobuf extensions.
+package protobuf
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// ContentTypeProtobuf content-type header for protobuf decoder.
+	ContentTypeProtobuf = "application/x-protobuf"
+	// ContentTypeProtobufAlt alternative content-type header for protobuf decoder.
+	ContentTypeProtobufAlt = "application/protobuf"
+)
+
+// OptionsFunc function for setting options.
+type OptionsFunc func(*Protobuf)
+
+// WithContentType sets content type.
+func WithContentType(contentType string) OptionsFunc {
+	return func(p *Protobuf) {
+		p.contentType = contentType
+	}
+}
+
+// WithUnmarshalOptions sets options of proto unmarshalling.
+func WithUnmarshalOptions(options proto.UnmarshalOptions) OptionsFunc {
+	return func(p *Protobuf) {
+		p.unmarshal = options
+	}
+}
+
+// Protobuf protobuf decoder.
+type Protobuf struct {
+	contentType string
+	unmarshal   proto.UnmarshalOptions
+}
+
+// NewProtobuf returns new protobuf decoder.
+//
+// To accept both content types register two decoders:
+//
+//	roamer.WithDecoders(
+//		protobuf.NewProtobuf(),
+//		protobuf.NewProtobuf(protobuf.WithContentType(protobuf.ContentTypeProtobufAlt)),
+//	)
+func NewProtobuf(opts ...OptionsFunc) *Protobuf {
+	p := Protobuf{
+		contentType: ContentTypeProtobuf,
+	}
+
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	return &p
+}
+
+// Decode decodes protobuf body from http request into ptr.
+//
+// ptr must implement proto.Message.
+func (p *Protobuf) Decode(r *http.Request, ptr any) error {
+	m, ok := ptr.(proto.Message)
+	if !ok {
+		return errors.Wrapf(rerr.NotSupported, "`%T` is not a proto message", ptr)
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.WithMessage(err, "read body")
+	}
+
+	if err := p.unmarshal.Unmarshal(data, m); err != nil {
+		return errors.WithMessage(err, "unmarshal proto message")
+	}
+
+	return nil
+}
+
+// ContentType returns content-type header value.
+func (p *Protobuf) ContentType() string {
+	return p.contentType
+}
@@ -0,0 +1,59 @@
+package protobuf
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestNewProtobuf(t *testing.T) {
+	p := NewProtobuf()
+	require.NotNil(t, p)
+	require.Equal(t, ContentTypeProtobuf, p.ContentType())
+
+	p = NewProtobuf(WithContentType(ContentTypeProtobufAlt))
+	require.NotNil(t, p)
+	require.Equal(t, ContentTypeProtobufAlt, p.ContentType())
+}
+
+func TestProtobuf_Decode(t *testing.T) {
+	body, err := proto.Marshal(wrapperspb.String("test"))
+	require.NoError(t, err)
+
+	newRequest := func(t *testing.T, body []byte) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", ContentTypeProtobuf)
+
+		return req
+	}
+
+	t.Run("proto message", func(t *testing.T) {
+		var v wrapperspb.StringValue
+		err := NewProtobuf().Decode(newRequest(t, body), &v)
+		require.NoError(t, err)
+		require.Equal(t, "test", v.GetValue())
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		var v wrapperspb.StringValue
+		err := NewProtobuf().Decode(newRequest(t, []byte{0xff}), &v)
+		require.Error(t, err)
+	})
+
+	t.Run("not a proto message", func(t *testing.T) {
+		var v struct {
+			Value string
+		}
+
+		err := NewProtobuf().Decode(newRequest(t, body), &v)
+		require.ErrorIs(t, err, rerr.NotSupported)
+	})
+}
@@ -0,0 +1,18 @@
+module github.com/slipros/roamer/pkg/protobuf
+
+go 1.22.0
+
+require (
+	github.com/pkg/errors v0.9.1
+	github.com/slipros/roamer v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.10.0
+	google.golang.org/protobuf v1.36.5
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/slipros/roamer => ../..
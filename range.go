@@ -0,0 +1,78 @@
+package roamer
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+const (
+	// TagRange range tag.
+	TagRange = "range"
+	// rangeSeparator separator of range bounds.
+	rangeSeparator = ".."
+)
+
+// validateRange checks that numeric field value is within inclusive bounds declared by range tag.
+//
+// Tag value format is `min..max`, any of the bounds can be omitted: `1..`, `..100`.
+// Validation is performed after binding, so it applies to fields filled by any source including body.
+// Fields which are not numbers are ignored, range tag may belong to other libraries.
+func validateRange(tag reflect.StructTag, fieldValue reflect.Value) error {
+	tagValue, ok := tag.Lookup(TagRange)
+	if !ok {
+		return nil
+	}
+
+	if fieldValue.Kind() == reflect.Pointer {
+		if fieldValue.IsNil() {
+			return nil
+		}
+
+		fieldValue = fieldValue.Elem()
+	}
+
+	var number float64
+	switch fieldValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = float64(fieldValue.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number = float64(fieldValue.Uint())
+	case reflect.Float32, reflect.Float64:
+		number = fieldValue.Float()
+	default:
+		return nil
+	}
+
+	low, high, found := strings.Cut(tagValue, rangeSeparator)
+	if !found {
+		return errors.Wrapf(rerr.InvalidTag, "%s:%q", TagRange, tagValue)
+	}
+
+	if len(low) > 0 {
+		minValue, err := strconv.ParseFloat(strings.TrimSpace(low), 64)
+		if err != nil {
+			return errors.Wrapf(rerr.InvalidTag, "%s:%q", TagRange, tagValue)
+		}
+
+		if number < minValue {
+			return errors.Wrapf(rerr.OutOfRange, "%v is less than %s", number, low)
+		}
+	}
+
+	if len(high) > 0 {
+		maxValue, err := strconv.ParseFloat(strings.TrimSpace(high), 64)
+		if err != nil {
+			return errors.Wrapf(rerr.InvalidTag, "%s:%q", TagRange, tagValue)
+		}
+
+		if number > maxValue {
+			return errors.Wrapf(rerr.OutOfRange, "%v is greater than %s", number, high)
+		}
+	}
+
+	return nil
+}
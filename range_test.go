@@ -0,0 +1,159 @@
+package roamer
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/slipros/roamer/decoder"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRange(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name    string
+		tag     reflect.StructTag
+		value   any
+		wantErr error
+	}{
+		{
+			name:  "no tag",
+			tag:   `query:"id"`,
+			value: 1000,
+		},
+		{
+			name:  "int lower bound inclusive",
+			tag:   `range:"1..100"`,
+			value: 1,
+		},
+		{
+			name:  "int upper bound inclusive",
+			tag:   `range:"1..100"`,
+			value: 100,
+		},
+		{
+			name:    "int less than lower bound",
+			tag:     `range:"1..100"`,
+			value:   0,
+			wantErr: rerr.OutOfRange,
+		},
+		{
+			name:    "int greater than upper bound",
+			tag:     `range:"1..100"`,
+			value:   101,
+			wantErr: rerr.OutOfRange,
+		},
+		{
+			name:  "uint open upper bound",
+			tag:   `range:"10.."`,
+			value: uint(1 << 20),
+		},
+		{
+			name:    "float greater than upper bound",
+			tag:     `range:"..0.5"`,
+			value:   0.51,
+			wantErr: rerr.OutOfRange,
+		},
+		{
+			name:  "float negative bounds",
+			tag:   `range:"-1.5..-0.5"`,
+			value: -1.5,
+		},
+		{
+			name:  "nil pointer",
+			tag:   `range:"1..100"`,
+			value: (*int)(nil),
+		},
+		{
+			name:    "pointer out of range",
+			tag:     `range:"1..100"`,
+			value:   intPtr(1000),
+			wantErr: rerr.OutOfRange,
+		},
+		{
+			name:    "invalid tag value",
+			tag:     `range:"1-100"`,
+			value:   1,
+			wantErr: rerr.InvalidTag,
+		},
+		{
+			name:  "not numeric field",
+			tag:   `range:"1,5"`,
+			value: "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRange(tt.tag, reflect.ValueOf(tt.value))
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRoamer_Parse_RangeValidation(t *testing.T) {
+	type Data struct {
+		Limit int     `query:"limit" range:"1..100"`
+		Ratio float64 `json:"ratio" range:"0..1"`
+	}
+
+	newRequest := func(t *testing.T, limit, body string) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com?"+url.Values{"limit": {limit}}.Encode(),
+			strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+		return req
+	}
+
+	r := NewRoamer(
+		WithDecoders(decoder.NewJSON()),
+		WithParsers(parser.NewQuery()),
+	)
+
+	var d Data
+	err := r.Parse(newRequest(t, "100", `{"ratio":0.5}`), &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Limit: 100, Ratio: 0.5}, d)
+
+	d = Data{}
+	err = r.Parse(newRequest(t, "101", `{"ratio":0.5}`), &d)
+	require.ErrorIs(t, err, rerr.OutOfRange)
+
+	parseErr, ok := IsParseError(err)
+	require.True(t, ok)
+	require.Equal(t, "Limit", parseErr.Field)
+
+	d = Data{}
+	err = r.Parse(newRequest(t, "1", `{"ratio":1.5}`), &d)
+	require.ErrorIs(t, err, rerr.OutOfRange)
+
+	parseErr, ok = IsParseError(err)
+	require.True(t, ok)
+	require.Equal(t, "Ratio", parseErr.Field)
+
+	d = Data{}
+	err = NewRoamer(WithDecoders(decoder.NewJSON())).Parse(newRequest(t, "1", `{"ratio":1.5}`), &d)
+	require.ErrorIs(t, err, rerr.OutOfRange, "body fields are validated without parsers")
+
+	type Other struct {
+		S    string   `query:"s" range:"1,5"`
+		Tags []string `query:"tags" range:"1..5"`
+	}
+
+	var o Other
+	err = r.Parse(newRequest(t, "1", `{}`), &o)
+	require.NoError(t, err, "range of not numeric fields is ignored")
+}
@@ -0,0 +1,45 @@
+package roamer
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Decoders returns a copy of decoders set by WithDecoders sorted by content type,
+// default decoder of WithDefaultDecoder is not included.
+func (r *Roamer) Decoders() []Decoder {
+	decoders := make([]Decoder, 0, len(r.decoders))
+	for _, d := range r.decoders {
+		decoders = append(decoders, d)
+	}
+
+	slices.SortFunc(decoders, func(a, b Decoder) int {
+		return cmp.Compare(a.ContentType(), b.ContentType())
+	})
+
+	return decoders
+}
+
+// Parsers returns a copy of parsers in order they were set by WithParsers.
+func (r *Roamer) Parsers() []Parser {
+	parsers := make([]Parser, 0, len(r.parserTags))
+	for _, tag := range r.parserTags {
+		parsers = append(parsers, r.parsers[tag])
+	}
+
+	return parsers
+}
+
+// Formatters returns a copy of formatters set by WithFormatters sorted by tag.
+func (r *Roamer) Formatters() []Formatter {
+	formatters := make([]Formatter, 0, len(r.formatters))
+	for _, f := range r.formatters {
+		formatters = append(formatters, f)
+	}
+
+	slices.SortFunc(formatters, func(a, b Formatter) int {
+		return cmp.Compare(a.Tag(), b.Tag())
+	})
+
+	return formatters
+}
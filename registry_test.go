@@ -0,0 +1,52 @@
+package roamer
+
+import (
+	"testing"
+
+	"github.com/slipros/roamer/decoder"
+	"github.com/slipros/roamer/formatter"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoamer_Registries(t *testing.T) {
+	jsonDecoder, xmlDecoder := decoder.NewJSON(), decoder.NewXML()
+	query, header := parser.NewQuery(), parser.NewHeader()
+	stringFormatter, timeFormatter := formatter.NewString(), formatter.NewTime()
+
+	r := NewRoamer(
+		WithDecoders(xmlDecoder, jsonDecoder),
+		WithDefaultDecoder(decoder.NewFormURL()),
+		WithParsers(query, header),
+		WithFormatters(timeFormatter, stringFormatter),
+	)
+
+	require.Equal(t, []Decoder{jsonDecoder, xmlDecoder}, r.Decoders())
+	require.Equal(t, []Parser{query, header}, r.Parsers())
+	require.Equal(t, []Formatter{stringFormatter, timeFormatter}, r.Formatters())
+
+	t.Run("Copies", func(t *testing.T) {
+		parsers := r.Parsers()
+		parsers[0] = nil
+		require.Equal(t, []Parser{query, header}, r.Parsers())
+
+		decoders := r.Decoders()
+		decoders[0] = nil
+		require.Equal(t, []Decoder{jsonDecoder, xmlDecoder}, r.Decoders())
+	})
+
+	t.Run("Replaced parser keeps its order", func(t *testing.T) {
+		newQuery := parser.NewQuery(parser.WithDisabledSplit())
+		c := r.With(WithParsers(parser.NewCookie(), newQuery))
+		require.Equal(t, []Parser{newQuery, header, c.Parsers()[2]}, c.Parsers())
+		require.Equal(t, parser.TagCookie, c.Parsers()[2].Tag())
+		require.Equal(t, []Parser{query, header}, r.Parsers())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		r := NewRoamer()
+		require.Empty(t, r.Decoders())
+		require.Empty(t, r.Parsers())
+		require.Empty(t, r.Formatters())
+	})
+}
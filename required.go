@@ -0,0 +1,57 @@
+package roamer
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/value"
+)
+
+const (
+	// TagRequired required tag, e.g. `required:"true"`.
+	TagRequired = "required"
+)
+
+// validateRequired checks that field declared as required by tag is not empty after parsing.
+//
+// Field is empty if it has zero value, e.g. nil pointer, empty string or zero number.
+func validateRequired(tag reflect.StructTag, fieldValue reflect.Value) error {
+	tagValue, ok := tag.Lookup(TagRequired)
+	if !ok {
+		return nil
+	}
+
+	required, err := strconv.ParseBool(tagValue)
+	if err != nil {
+		return errors.Wrapf(rerr.InvalidTag, "%s:%q", TagRequired, tagValue)
+	}
+
+	if required && fieldValue.IsZero() {
+		return errors.WithStack(rerr.MissingValue)
+	}
+
+	return nil
+}
+
+// isBlank reports whether parsed value is empty or consists of whitespaces only.
+func isBlank(parsedValue any) bool {
+	switch v := parsedValue.(type) {
+	case string:
+		return len(strings.TrimSpace(v)) == 0
+	case []string:
+		for _, str := range v {
+			if len(strings.TrimSpace(str)) > 0 {
+				return false
+			}
+		}
+
+		return true
+	case value.MultiValue:
+		return isBlank(v.Strings())
+	}
+
+	return false
+}
@@ -0,0 +1,280 @@
+package roamer
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/slipros/roamer/value"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRequired(t *testing.T) {
+	str := "value"
+
+	tests := []struct {
+		name    string
+		tag     reflect.StructTag
+		value   any
+		wantErr error
+	}{
+		{
+			name:  "no tag",
+			tag:   `query:"name"`,
+			value: "",
+		},
+		{
+			name:  "filled",
+			tag:   `required:"true"`,
+			value: "value",
+		},
+		{
+			name:    "empty",
+			tag:     `required:"true"`,
+			value:   "",
+			wantErr: rerr.MissingValue,
+		},
+		{
+			name:    "nil pointer",
+			tag:     `required:"true"`,
+			value:   (*string)(nil),
+			wantErr: rerr.MissingValue,
+		},
+		{
+			name:  "pointer",
+			tag:   `required:"true"`,
+			value: &str,
+		},
+		{
+			name:  "not required",
+			tag:   `required:"false"`,
+			value: "",
+		},
+		{
+			name:    "invalid tag",
+			tag:     `required:"yes please"`,
+			value:   "",
+			wantErr: rerr.InvalidTag,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRequired(tt.tag, reflect.ValueOf(tt.value))
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRoamer_Parse_IdempotencyKey(t *testing.T) {
+	type Data struct {
+		IdempotencyKey string `header:"Idempotency-Key" required:"true" format:"uuid"`
+		Token          string `header:"X-Token" format:"len=8..16"`
+	}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    Data
+		wantErr error
+	}{
+		{
+			name:    "valid",
+			headers: map[string]string{"Idempotency-Key": "F47AC10B-58CC-4372-A567-0E02B2C3D479", "X-Token": "12345678"},
+			want:    Data{IdempotencyKey: "F47AC10B-58CC-4372-A567-0E02B2C3D479", Token: "12345678"},
+		},
+		{
+			name:    "missing",
+			wantErr: rerr.MissingValue,
+		},
+		{
+			name:    "malformed",
+			headers: map[string]string{"Idempotency-Key": "f47ac10b58cc4372a5670e02b2c3d479"},
+			wantErr: rerr.InvalidFormat,
+		},
+		{
+			name: "too long",
+			headers: map[string]string{
+				"Idempotency-Key": "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+				"X-Token":         "12345678901234567",
+			},
+			wantErr: rerr.InvalidFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "test.com", nil)
+			require.NoError(t, err)
+
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			var d Data
+			err = NewRoamer(WithParsers(parser.NewHeader())).Parse(req, &d)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				parseErr, ok := IsParseError(err)
+				require.True(t, ok)
+				require.Contains(t, []string{"IdempotencyKey", "Token"}, parseErr.Field)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestRoamer_Parse_TreatBlankAsEmpty(t *testing.T) {
+	type Data struct {
+		Name  string   `query:"name" required:"true"`
+		Sort  string   `query:"sort" default:"asc"`
+		Tags  []string `query:"tags"`
+		Title string   `query:"title" header:"X-Title"`
+	}
+
+	tests := []struct {
+		name    string
+		opts    []OptionsFunc
+		query   string
+		title   string
+		want    Data
+		wantErr error
+	}{
+		{
+			name:  "blank values are set by default",
+			query: "name=%20%20&sort=%20",
+			want:  Data{Name: "  ", Sort: " "},
+		},
+		{
+			name:    "blank value fails required",
+			opts:    []OptionsFunc{WithTreatBlankAsEmpty()},
+			query:   "name=%20%20",
+			wantErr: rerr.MissingValue,
+		},
+		{
+			name:  "blank value is replaced by default",
+			opts:  []OptionsFunc{WithTreatBlankAsEmpty()},
+			query: "name=test&sort=%09",
+			want:  Data{Name: "test", Sort: "asc"},
+		},
+		{
+			name:  "blank values of slice are absent",
+			opts:  []OptionsFunc{WithTreatBlankAsEmpty()},
+			query: "name=test&tags=%20&tags=",
+			want:  Data{Name: "test", Sort: "asc"},
+		},
+		{
+			name:  "blank value is filled from other source",
+			opts:  []OptionsFunc{WithTreatBlankAsEmpty()},
+			query: "name=test&title=%20",
+			title: "header",
+			want:  Data{Name: "test", Sort: "asc", Title: "header"},
+		},
+		{
+			name:  "value with surrounding spaces is kept",
+			opts:  []OptionsFunc{WithTreatBlankAsEmpty()},
+			query: "name=%20test%20",
+			want:  Data{Name: " test ", Sort: "asc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?"+tt.query, nil)
+			require.NoError(t, err)
+			if len(tt.title) > 0 {
+				req.Header.Set("X-Title", tt.title)
+			}
+
+			opts := append([]OptionsFunc{WithParsers(parser.NewQuery(), parser.NewHeader())}, tt.opts...)
+
+			var d Data
+			err = NewRoamer(opts...).Parse(req, &d)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+type testEmail string
+
+func TestRoamer_Parse_RegisteredValidator(t *testing.T) {
+	errInvalidEmail := errors.New("invalid email")
+
+	value.RegisterValidator(reflect.TypeOf(testEmail("")), func(v any) error {
+		if !strings.Contains(string(v.(testEmail)), "@") {
+			return errInvalidEmail
+		}
+
+		return nil
+	})
+	t.Cleanup(func() {
+		value.RegisterValidator(reflect.TypeOf(testEmail("")), nil)
+	})
+
+	type Data struct {
+		Email   testEmail  `query:"email"`
+		Backup  *testEmail `query:"backup"`
+		Contact testEmail  `query:"contact"`
+	}
+
+	backup := testEmail("backup@example.com")
+
+	tests := []struct {
+		name    string
+		query   string
+		want    Data
+		wantErr error
+	}{
+		{
+			name:  "valid",
+			query: "email=user@example.com&backup=backup@example.com",
+			want:  Data{Email: "user@example.com", Backup: &backup},
+		},
+		{
+			name:    "invalid",
+			query:   "email=user",
+			wantErr: errInvalidEmail,
+		},
+		{
+			name:    "invalid pointer",
+			query:   "email=user@example.com&backup=backup",
+			wantErr: errInvalidEmail,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?"+tt.query, nil)
+			require.NoError(t, err)
+
+			var d Data
+			err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &d)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				parseErr, ok := IsParseError(err)
+				require.True(t, ok)
+				require.NotEmpty(t, parseErr.Field)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package roamer
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+const (
+	// TagResolve resolve tag of interface fields filled with value of type resolver, e.g. `resolve:"true"`.
+	TagResolve = "resolve"
+)
+
+// TypeResolver returns pointer to a new value of concrete type for polymorphic body,
+// e.g. chosen by discriminator header.
+type TypeResolver = func(r *http.Request) (any, error)
+
+// resolveType returns pointer returned by type resolver checking that it is assignable to type t.
+func (r *Roamer) resolveType(req *http.Request, t reflect.Type) (reflect.Value, error) {
+	resolved, err := r.typeResolver(req)
+	if err != nil {
+		return reflect.Value{}, errors.WithMessage(err, "resolve type")
+	}
+
+	if resolved == nil {
+		return reflect.Value{}, errors.Wrapf(rerr.NilValue, "resolved type for `%s`", t)
+	}
+
+	v := reflect.ValueOf(resolved)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return reflect.Value{}, errors.Wrapf(rerr.NotPtr, "resolved `%T` for `%s`", resolved, t)
+	}
+
+	if !v.Type().AssignableTo(t) {
+		return reflect.Value{}, errors.Wrapf(rerr.NotSupported, "resolved `%T` is not assignable to `%s`", resolved, t)
+	}
+
+	return v, nil
+}
+
+// parseInterface parses http request into value of type returned by type resolver
+// and sets it into interface pointed by ptr.
+func (r *Roamer) parseInterface(req *http.Request, ptr any) error {
+	v := reflect.ValueOf(ptr).Elem()
+
+	resolved, err := r.resolveType(req, v.Type())
+	if err != nil {
+		return err
+	}
+
+	v.Set(resolved)
+
+	if resolved.Elem().Kind() == reflect.Struct {
+		return r.ParseValue(req, resolved.Elem())
+	}
+
+	if _, err := r.parseBody(req, resolved.Interface()); err != nil {
+		return err
+	}
+
+	return r.afterParse(req, resolved.Interface())
+}
+
+// resolveFields sets values of type resolver into nil interface fields of struct with resolve tag,
+// so decoder fills values of concrete type.
+func (r *Roamer) resolveFields(req *http.Request, ptr any) error {
+	v := reflect.Indirect(reflect.ValueOf(ptr))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	for i := range t.NumField() {
+		fieldType := t.Field(i)
+		if fieldType.Type.Kind() != reflect.Interface || !fieldType.IsExported() {
+			continue
+		}
+
+		tagValue, ok := fieldType.Tag.Lookup(TagResolve)
+		if !ok {
+			continue
+		}
+
+		resolve, err := strconv.ParseBool(tagValue)
+		if err != nil {
+			return errors.WithStack(rerr.ParseError{
+				Field: fieldType.Name,
+				Err:   errors.Wrapf(rerr.InvalidTag, "%s:%q", TagResolve, tagValue),
+			})
+		}
+
+		fieldValue := v.Field(i)
+		if !resolve || !fieldValue.IsNil() {
+			continue
+		}
+
+		resolved, err := r.resolveType(req, fieldType.Type)
+		if err != nil {
+			return errors.WithStack(rerr.ParseError{Field: fieldType.Name, Err: err})
+		}
+
+		fieldValue.Set(resolved)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package roamer
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/slipros/roamer/decoder"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+type testMessage interface {
+	Kind() string
+}
+
+type testTextMessage struct {
+	Text   string `json:"text"`
+	Author string `header:"X-Author"`
+}
+
+func (m *testTextMessage) Kind() string {
+	return "text"
+}
+
+type testImageMessage struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Author string `header:"X-Author"`
+}
+
+func (m *testImageMessage) Kind() string {
+	return "image"
+}
+
+func testMessageResolver(r *http.Request) (any, error) {
+	switch r.Header.Get("X-Message-Type") {
+	case "text":
+		return &testTextMessage{}, nil
+	case "image":
+		return &testImageMessage{}, nil
+	case "broken":
+		return testTextMessage{}, nil
+	case "nil":
+		return nil, nil
+	default:
+		return nil, errors.New("unknown message type")
+	}
+}
+
+func TestRoamer_Parse_TypeResolver(t *testing.T) {
+	tests := []struct {
+		name        string
+		messageType string
+		body        string
+		want        testMessage
+		wantErr     error
+	}{
+		{
+			name:        "text",
+			messageType: "text",
+			body:        `{"text":"hello"}`,
+			want:        &testTextMessage{Text: "hello", Author: "bob"},
+		},
+		{
+			name:        "image",
+			messageType: "image",
+			body:        `{"url":"https://test.com/cat.png","width":100}`,
+			want:        &testImageMessage{URL: "https://test.com/cat.png", Width: 100, Author: "bob"},
+		},
+		{
+			name:        "not a pointer",
+			messageType: "broken",
+			body:        `{"text":"hello"}`,
+			wantErr:     rerr.NotPtr,
+		},
+		{
+			name:        "nil",
+			messageType: "nil",
+			body:        `{"text":"hello"}`,
+			wantErr:     rerr.NilValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+			req.Header.Set("X-Message-Type", tt.messageType)
+			req.Header.Set("X-Author", "bob")
+
+			r := NewRoamer(
+				WithDecoders(decoder.NewJSON()),
+				WithParsers(parser.NewHeader()),
+				WithTypeResolver(testMessageResolver),
+			)
+
+			var m testMessage
+			err = r.Parse(req, &m)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, m)
+		})
+	}
+
+	var m testMessage
+	err := NewRoamer(WithDecoders(decoder.NewJSON())).Parse(&http.Request{}, &m)
+	require.ErrorIs(t, err, rerr.NotSupported)
+}
+
+func TestRoamer_Parse_TypeResolver_Field(t *testing.T) {
+	type Envelope struct {
+		ID      string      `json:"id"`
+		Message testMessage `json:"message" resolve:"true"`
+		Extra   any         `json:"extra"`
+	}
+
+	newRequest := func(t *testing.T, messageType, body string) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+		req.Header.Set("X-Message-Type", messageType)
+
+		return req
+	}
+
+	r := NewRoamer(WithDecoders(decoder.NewJSON()), WithTypeResolver(testMessageResolver))
+
+	var e Envelope
+	err := r.Parse(newRequest(t, "image", `{"id":"1","message":{"url":"cat.png","width":10},"extra":{"a":1}}`), &e)
+	require.NoError(t, err)
+	require.Equal(t, Envelope{
+		ID:      "1",
+		Message: &testImageMessage{URL: "cat.png", Width: 10},
+		Extra:   map[string]any{"a": float64(1)},
+	}, e)
+
+	e = Envelope{}
+	err = r.Parse(newRequest(t, "unknown", `{"id":"1"}`), &e)
+	pErr, ok := IsParseError(err)
+	require.True(t, ok)
+	require.Equal(t, "Message", pErr.Field)
+
+	type Invalid struct {
+		Message testMessage `json:"message" resolve:"yes"`
+	}
+
+	err = r.Parse(newRequest(t, "text", `{}`), &Invalid{})
+	require.ErrorIs(t, err, rerr.InvalidTag)
+}
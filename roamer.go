@@ -2,12 +2,17 @@
 package roamer
 
 import (
+	"bytes"
+	"io"
+	"maps"
 	"net/http"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
-	"github.com/slipros/exp"
 	rerr "github.com/slipros/roamer/err"
 	rexp "github.com/slipros/roamer/internal/experiment"
 	"github.com/slipros/roamer/parser"
@@ -21,140 +26,594 @@ type AfterParser interface {
 	AfterParse(r *http.Request) error
 }
 
+const (
+	// TagEncoding encoding tag of bytes fields, e.g. `encoding:"hex"`.
+	TagEncoding = "encoding"
+)
+
+// ValidatorFunc validates parsed value, e.g. by go-playground/validator.
+type ValidatorFunc = func(ptr any) error
+
 // Roamer flexible http request parser.
 type Roamer struct {
 	parsers                     Parsers
+	parserTags                  []string
 	decoders                    Decoders
+	defaultDecoder              Decoder
 	formatters                  Formatters
 	skipFilled                  bool
+	treatBlankAsEmpty           bool
+	hasMeta                     bool
 	hasParsers                  bool
 	hasDecoders                 bool
 	hasFormatters               bool
 	experimentalFastStructField bool
+	preserveBody                bool
+	preserveBodyPool            *sync.Pool
+	contentDecoding             bool
+	requestSizeLimit            int64
+	fieldNameMapper             FieldNameMapper
+	fieldNameMatcher            parser.FieldNameMatcher
+	allowedFields               map[string]struct{}
+	protectedFields             map[string]struct{}
+	cacheFactory                func() parser.Cache
+	cacheRelease                func(parser.Cache)
+	defaultFuncs                DefaultFuncs
+	validator                   ValidatorFunc
+	relativeTimeAnchor          RelativeTimeAnchor
+	logger                      Logger
+	structFieldsCache           *structFieldsCache
+	typeResolver                TypeResolver
+	strictTags                  bool
+	strictAllowedTags           []string
+	strictTagsCache             *strictTagsCache
+	usageTracking               bool
+	usage                       *usageTracker
 }
 
 // NewRoamer creates and returns new roamer.
 func NewRoamer(opts ...OptionsFunc) *Roamer {
 	r := Roamer{
-		parsers:    make(Parsers),
-		decoders:   make(Decoders),
-		formatters: make(Formatters),
-		skipFilled: true,
+		parsers:      make(Parsers),
+		decoders:     make(Decoders),
+		formatters:   make(Formatters),
+		defaultFuncs: maps.Clone(defaultFuncs),
+		skipFilled:   true,
 	}
 
+	r.apply(opts...)
+
+	return &r
+}
+
+// With returns a copy of roamer with applied options, e.g. with additional decoder.
+//
+// Instances of parsers, decoders and formatters are shared with the original roamer, which stays unchanged.
+func (r *Roamer) With(opts ...OptionsFunc) *Roamer {
+	c := *r
+	c.parsers = maps.Clone(r.parsers)
+	c.parserTags = slices.Clone(r.parserTags)
+	c.decoders = maps.Clone(r.decoders)
+	c.formatters = maps.Clone(r.formatters)
+	c.allowedFields = maps.Clone(r.allowedFields)
+	c.protectedFields = maps.Clone(r.protectedFields)
+	c.defaultFuncs = maps.Clone(r.defaultFuncs)
+	c.strictAllowedTags = slices.Clone(r.strictAllowedTags)
+
+	c.apply(opts...)
+
+	return &c
+}
+
+// apply applies options.
+func (r *Roamer) apply(opts ...OptionsFunc) {
 	for _, opt := range opts {
-		opt(&r)
+		opt(r)
 	}
 
 	r.hasParsers = len(r.parsers) > 0
-	r.hasDecoders = len(r.decoders) > 0
+	r.hasDecoders = len(r.decoders) > 0 || r.defaultDecoder != nil
 	r.hasFormatters = len(r.formatters) > 0
+	_, r.hasMeta = r.parsers[parser.TagMeta]
+
+	// options can change fields which are filled by parsers.
+	r.structFieldsCache = new(structFieldsCache)
+	r.strictTagsCache = new(strictTagsCache)
+
+	if r.usageTracking {
+		r.usage = new(usageTracker)
+	}
 
 	if r.experimentalFastStructField {
 		r.enableExperimentalFeatures()
 	}
-
-	return &r
 }
 
 // Parse parses http request into ptr.
 //
-// ptr can implement AfterParser to execute some logic after parsing.
+// Body is decoded first, then parsers fill struct fields and formatters are applied,
+// which is the same as calling ParseBody and then ParseParsers.
+//
+// Field filled from body is not changed by parsers, unless its parser tag has override option,
+// e.g. `json:"limit" query:"limit,override"`, then value of parser is used if it is present.
+// Field with several parser tags is filled by the first parser, in order of WithParsers, which returned a value.
+//
+// Decoder is chosen by media type of Content-Type header, body with missing or unmatched content type
+// is decoded by default decoder if it is set, otherwise rerr.UnsupportedContentType is returned.
+//
+// ptr can implement AfterParser to execute some logic after parsing,
+// validator set by WithValidator is called last.
+//
+// ptr to a slice, array or map, e.g. top-level json array into *[]T, is only decoded from body,
+// parsers and formatters are skipped since there are no struct fields to fill.
+//
+// ptr to an interface is filled with value of type resolver set by WithTypeResolver,
+// which is parsed instead of ptr.
 func (r *Roamer) Parse(req *http.Request, ptr any) error {
-	if ptr == nil {
-		return errors.Wrapf(rerr.NilValue, "ptr")
-	}
-
-	t := reflect.TypeOf(ptr)
-	if t.Kind() != reflect.Pointer {
-		return errors.Wrapf(rerr.NotPtr, "`%T`", ptr)
+	t, err := ptrType(ptr)
+	if err != nil {
+		return err
 	}
 
 	switch t.Elem().Kind() {
 	case reflect.Struct:
-		if err := r.parseStruct(req, ptr); err != nil {
-			return err
-		}
+		return r.ParseValue(req, reflect.ValueOf(ptr).Elem())
 	case reflect.Slice, reflect.Array, reflect.Map:
-		if err := r.parseBody(req, ptr); err != nil {
+		if _, err := r.parseBody(req, ptr); err != nil {
 			return err
 		}
+	case reflect.Interface:
+		if r.typeResolver == nil {
+			return errors.Wrapf(rerr.NotSupported, "`%T` without type resolver", ptr)
+		}
+
+		return r.parseInterface(req, ptr)
 	default:
 		return errors.Wrapf(rerr.NotSupported, "`%T`", ptr)
 	}
 
+	return r.afterParse(req, ptr)
+}
+
+// ParseValue parses http request into struct value the same way as Parse,
+// e.g. for frameworks already holding reflect.Value of destination.
+//
+// v must be a settable struct, e.g. reflect.ValueOf(&dst).Elem().
+func (r *Roamer) ParseValue(req *http.Request, v reflect.Value) error {
+	if v.Kind() != reflect.Struct {
+		return errors.Wrapf(rerr.NotSupported, "`%s`", v.Kind())
+	}
+
+	if !v.CanSet() {
+		return errors.Wrapf(rerr.NotSettable, "`%s`", v.Type())
+	}
+
+	ptr := v.Addr().Interface()
+	if err := r.parseStruct(req, ptr); err != nil {
+		return err
+	}
+
+	return r.afterParse(req, ptr)
+}
+
+// afterParse calls AfterParser of ptr and validator.
+func (r *Roamer) afterParse(req *http.Request, ptr any) error {
 	if p, ok := ptr.(AfterParser); ok {
-		return p.AfterParse(req)
+		if err := p.AfterParse(req); err != nil {
+			return err
+		}
+	}
+
+	if r.validator != nil {
+		if err := r.validator(ptr); err != nil {
+			return errors.WithStack(rerr.ValidationError{Err: err})
+		}
 	}
 
 	return nil
 }
 
+// ParseBody decodes only body of http request into ptr, parsers and formatters are not run.
+func (r *Roamer) ParseBody(req *http.Request, ptr any) error {
+	t, err := ptrType(ptr)
+	if err != nil {
+		return err
+	}
+
+	switch t.Elem().Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		_, err := r.parseBody(req, ptr)
+		return err
+	default:
+		return errors.Wrapf(rerr.NotSupported, "`%T`", ptr)
+	}
+}
+
+// ParseParsers fills struct fields of ptr only by parsers with applied formatters, body is not decoded.
+//
+// ptr must be a pointer to a struct.
+func (r *Roamer) ParseParsers(req *http.Request, ptr any) error {
+	t, err := ptrType(ptr)
+	if err != nil {
+		return err
+	}
+
+	if t.Elem().Kind() != reflect.Struct {
+		return errors.Wrapf(rerr.NotSupported, "`%T`", ptr)
+	}
+
+	return r.parseFields(req, ptr, nil)
+}
+
+// ptrType returns type of ptr checking that it is a non nil pointer, including typed nil pointer.
+func ptrType(ptr any) (reflect.Type, error) {
+	if ptr == nil {
+		return nil, errors.Wrapf(rerr.NilValue, "ptr")
+	}
+
+	t := reflect.TypeOf(ptr)
+	if t.Kind() != reflect.Pointer {
+		return nil, errors.Wrapf(rerr.NotPtr, "`%T`", ptr)
+	}
+
+	if reflect.ValueOf(ptr).IsNil() {
+		return nil, errors.Wrapf(rerr.NilValue, "`%T`", ptr)
+	}
+
+	return t, nil
+}
+
 // parseStruct parses structure from http request into a ptr.
 func (r *Roamer) parseStruct(req *http.Request, ptr any) error {
-	if err := r.parseBody(req, ptr); err != nil {
+	if r.strictTags {
+		if err := r.checkTags(reflect.TypeOf(ptr).Elem()); err != nil {
+			return err
+		}
+	}
+
+	var body *countingReader
+	if r.hasMeta && req.Body != nil {
+		body = &countingReader{ReadCloser: req.Body}
+		req.Body = body
+
+		defer func() {
+			if req.Body == body {
+				req.Body = body.ReadCloser
+			}
+		}()
+	}
+
+	data, err := r.parseBody(req, ptr)
+	if err != nil {
 		return err
 	}
 
-	if !r.hasParsers {
-		return nil
+	if (body == nil || body.n == 0) && (data == nil || !r.hasMeta) {
+		return r.parseFields(req, ptr, nil)
+	}
+
+	cache := r.newCache(0)
+	defer r.releaseCache(cache)
+
+	if body != nil && body.n > 0 {
+		cache[parser.CacheKeyBodyLength] = body.n
+	}
+
+	if data != nil && r.hasMeta {
+		cache[parser.CacheKeyBody] = data
+	}
+
+	return r.parseFields(req, ptr, cache)
+}
+
+// newCache returns cache of parsers for a single request.
+func (r *Roamer) newCache(size int) parser.Cache {
+	var cache parser.Cache
+	if r.cacheFactory != nil {
+		cache = r.cacheFactory()
+	}
+
+	if cache == nil {
+		cache = make(parser.Cache, size)
+	}
+
+	if r.fieldNameMatcher != nil {
+		cache[parser.CacheKeyFieldNameMatcher] = r.fieldNameMatcher
 	}
 
+	return cache
+}
+
+// releaseCache releases cache of parsers after a request is parsed.
+func (r *Roamer) releaseCache(cache parser.Cache) {
+	if r.cacheRelease != nil {
+		r.cacheRelease(cache)
+	}
+}
+
+// parseFields fills fields of structure from http request by parsers and applies formatters.
+func (r *Roamer) parseFields(req *http.Request, ptr any, cache parser.Cache) error {
 	v := reflect.Indirect(reflect.ValueOf(ptr))
-	t := v.Type()
 
-	var fieldType reflect.StructField
+	fields, err := r.structFields(&v)
+	if err != nil {
+		return err
+	}
+
+	if cache == nil {
+		cache = r.newCache(len(fields))
+		defer r.releaseCache(cache)
+	}
 
-	fieldsAmount := v.NumField()
-	cache := make(parser.Cache, fieldsAmount)
+	for i := range fields {
+		f := &fields[i]
+		if f.denied {
+			r.consume(req, &f.field, cache, 0)
 
-	for i := range fieldsAmount {
-		if r.experimentalFastStructField {
-			ft, exists := exp.FastStructField(&v, i)
-			if !exists {
-				// should never happen - anomaly.
-				return errors.WithStack(rerr.FieldIndexOutOfBounds)
+			if err := r.completeDeniedField(&f.field, v.Field(f.index), ptr); err != nil {
+				return err
 			}
 
-			fieldType = ft
-		} else {
-			fieldType = t.Field(i)
+			continue
 		}
 
-		if !fieldType.IsExported() || len(fieldType.Tag) == 0 {
-			continue
+		if err := r.parseField(req, &f.field, v.Field(f.index), cache, ptr); err != nil {
+			return err
 		}
+	}
 
-		fieldValue := v.Field(i)
-		if r.skipFilled && !fieldValue.IsZero() {
-			if r.hasFormatters {
-				if err := r.formatFieldValue(&fieldType, fieldValue); err != nil {
-					return errors.WithMessagef(err, "format field `%s` in struct `%T`", fieldType.Name, ptr)
-				}
+	return nil
+}
+
+// consume marks values of field as consumed by consuming parsers starting from parser tag with index from,
+// the value of field is not parsed by them.
+func (r *Roamer) consume(req *http.Request, fieldType *reflect.StructField, cache parser.Cache, from int) {
+	for _, tag := range r.parserTags[from:] {
+		if c, ok := r.parsers[tag].(ConsumingParser); ok {
+			c.Consume(req, fieldType.Tag, cache)
+		}
+	}
+}
+
+// parseField parses field value from http request.
+func (r *Roamer) parseField(
+	req *http.Request,
+	fieldType *reflect.StructField,
+	fieldValue reflect.Value,
+	cache parser.Cache,
+	ptr any,
+) error {
+	if r.hasMeta && !r.preserveBody {
+		if key, ok := fieldType.Tag.Lookup(parser.TagMeta); ok && parser.IsMetaBodyHash(key) {
+			return errors.WithStack(rerr.ParseError{
+				Field: fieldType.Name,
+				Err:   errors.Wrapf(rerr.BodyNotPreserved, "meta `%s` requires WithPreserveBody option", key),
+			})
+		}
+	}
+
+	if r.skipFilled && !fieldValue.IsZero() && !r.isOverride(fieldType) {
+		r.consume(req, fieldType, cache, 0)
+
+		return r.completeField(fieldType, fieldValue, ptr)
+	}
+
+	parsed := false
+	for i, tag := range r.parserTags {
+		p := r.parsers[tag]
+		var (
+			parsedValue any
+			ok          bool
+		)
+
+		switch tp := p.(type) {
+		case DestinationParser:
+			parsedValue, ok = tp.ParseWithDestination(req, fieldType.Tag, cache, reflect.Indirect(reflect.ValueOf(ptr)))
+		case ErrorParser:
+			var err error
+			parsedValue, ok, err = tp.ParseWithError(req, fieldType.Tag, cache)
+			if err != nil {
+				return errors.WithStack(rerr.ParseError{
+					Field: fieldType.Name,
+					Err:   errors.WithMessagef(err, "parse from tag `%s` for struct `%T`", tag, ptr),
+				})
 			}
+		default:
+			parsedValue, ok = p.Parse(req, fieldType.Tag, cache)
+		}
 
+		if !ok || (r.treatBlankAsEmpty && isBlank(parsedValue)) {
 			continue
 		}
 
-		for tag, p := range r.parsers {
-			parsedValue, ok := p.Parse(req, fieldType.Tag, cache)
-			if !ok {
-				continue
+		r.markUsed(ComponentParser, tag)
+
+		if err := r.setFieldValue(req, fieldType, fieldValue, parsedValue, tag, ptr); err != nil {
+			if r.logger != nil {
+				r.logger.Debug("roamer: set field value failed", "field", fieldType.Name, "tag", tag, "error", err)
 			}
 
-			if err := value.Set(fieldValue, parsedValue); err != nil {
-				return errors.Wrapf(err, "set `%s` value to field `%s` from tag `%s` for struct `%T`",
-					parsedValue, fieldType.Name, tag, ptr)
+			return errors.Wrapf(err, "set `%s` value to field `%s` from tag `%s` for struct `%T`",
+				parsedValue, fieldType.Name, tag, ptr)
+		}
+
+		r.consume(req, fieldType, cache, i+1)
+
+		parsed = true
+		break
+	}
+
+	if !parsed {
+		if r.hasParsers && r.logger != nil {
+			r.logNotParsed(fieldType)
+		}
+
+		if err := r.setAbsentFlag(fieldType, fieldValue); err != nil {
+			return err
+		}
+
+		if err := r.setDefault(fieldType, fieldValue); err != nil {
+			return err
+		}
+	}
+
+	return r.completeField(fieldType, fieldValue, ptr)
+}
+
+// isOverride reports whether field has override option in tag of any parser.
+func (r *Roamer) isOverride(fieldType *reflect.StructField) bool {
+	for _, tag := range r.parserTags {
+		if tagValue, ok := fieldType.Tag.Lookup(tag); ok {
+			if _, opts := parser.SplitTagValue(tagValue); opts.Has(parser.TagOptionOverride) {
+				return true
 			}
+		}
+	}
 
-			break
+	return false
+}
+
+// setFieldValue sets parsed value into a field according to field tags.
+func (r *Roamer) setFieldValue(
+	req *http.Request,
+	fieldType *reflect.StructField,
+	fieldValue reflect.Value,
+	parsedValue any,
+	tag string,
+	ptr any,
+) error {
+	_, opts := parser.SplitTagValue(fieldType.Tag.Get(tag))
+	if len(opts) > 0 {
+		if opts.SkipValue(parsedValue) {
+			return nil
+		}
+
+		if opts.IsNull(parsedValue) {
+			fieldValue.SetZero()
+			return nil
+		}
+
+		if err := checkTagOptions(opts, parsedValue); err != nil {
+			return errors.WithStack(rerr.ParseError{Field: fieldType.Name, Err: err})
+		}
+
+		converted, err := opts.ConvertKeys(parsedValue)
+		if err != nil {
+			return errors.WithStack(rerr.ParseError{Field: fieldType.Name, Err: err})
+		}
+
+		parsedValue = converted
+
+		if opts.IsFlag() {
+			flag, err := opts.FlagValue(parsedValue, true)
+			if err != nil {
+				return errors.WithStack(rerr.ParseError{Field: fieldType.Name, Err: err})
+			}
+
+			parsedValue = strconv.FormatBool(flag)
 		}
 
-		if r.hasFormatters {
-			if err := r.formatFieldValue(&fieldType, fieldValue); err != nil {
-				return errors.WithMessagef(err, "format field `%s` in struct `%T`", fieldType.Name, ptr)
+		if opts.Has(parser.TagOptionBase64JSON) {
+			if str, ok := parsedValue.(string); ok {
+				return value.SetBase64JSONString(fieldValue, str)
 			}
 		}
+
+		if opts.Has(parser.TagOptionStrict) {
+			switch v := parsedValue.(type) {
+			case string:
+				return value.SetKeyValueString(fieldValue, v, true)
+			case []string:
+				return value.SetKeyValues(fieldValue, v, true)
+			}
+		}
+	}
+
+	if setter, ok := fieldType.Tag.Lookup(TagSetter); ok {
+		if err := callSetter(ptr, setter, parsedValue); err != nil {
+			return errors.WithStack(rerr.ParseError{Field: fieldType.Name, Err: err})
+		}
+
+		return nil
+	}
+
+	if err := checkStrictBool(fieldType.Tag, fieldValue, parsedValue); err != nil {
+		return errors.WithStack(rerr.ParseError{Field: fieldType.Name, Err: err})
+	}
+
+	if encoding, ok := fieldType.Tag.Lookup(TagEncoding); ok {
+		if str, ok := parsedValue.(string); ok {
+			return value.SetEncodedString(fieldValue, str, encoding)
+		}
+	}
+
+	if str, ok := parsedValue.(string); ok && r.hasFormatters {
+		parsed, ok, err := r.parseString(req, fieldType, str)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			parsedValue = parsed
+		}
+	}
+
+	return value.Set(fieldValue, parsedValue)
+}
+
+// checkTagOptions checks parsed value against options of parser tag.
+func checkTagOptions(opts parser.TagOptions, parsedValue any) error {
+	switch v := parsedValue.(type) {
+	case []string:
+		return parser.CheckMaxItems(opts, len(v))
+	case value.MultiValue:
+		return parser.CheckMaxItems(opts, len(v.Strings()))
+	}
+
+	return nil
+}
+
+// completeField formats and validates field value.
+func (r *Roamer) completeField(fieldType *reflect.StructField, fieldValue reflect.Value, ptr any) error {
+	if r.hasFormatters {
+		if err := r.formatFieldValue(fieldType, fieldValue); err != nil {
+			return errors.WithMessagef(err, "format field `%s` in struct `%T`", fieldType.Name, ptr)
+		}
+	}
+
+	if err := validateRange(fieldType.Tag, fieldValue); err != nil {
+		return errors.WithStack(rerr.ParseError{
+			Field: fieldType.Name,
+			Err:   errors.WithMessagef(err, "validate field in struct `%T`", ptr),
+		})
+	}
+
+	if err := validateRequired(fieldType.Tag, fieldValue); err != nil {
+		return errors.WithStack(rerr.ParseError{
+			Field: fieldType.Name,
+			Err:   errors.WithMessagef(err, "validate field in struct `%T`", ptr),
+		})
+	}
+
+	if err := validateFormat(fieldType.Tag, fieldValue); err != nil {
+		return errors.WithStack(rerr.ParseError{
+			Field: fieldType.Name,
+			Err:   errors.WithMessagef(err, "validate field in struct `%T`", ptr),
+		})
+	}
+
+	if err := validateOneOf(fieldType.Tag, fieldValue); err != nil {
+		return errors.WithStack(rerr.ParseError{
+			Field: fieldType.Name,
+			Err:   errors.WithMessagef(err, "validate field in struct `%T`", ptr),
+		})
+	}
+
+	if err := value.Validate(fieldValue); err != nil {
+		return errors.WithStack(rerr.ParseError{
+			Field: fieldType.Name,
+			Err:   errors.WithMessagef(err, "validate field in struct `%T`", ptr),
+		})
 	}
 
 	return nil
@@ -171,19 +630,27 @@ func (r *Roamer) formatFieldValue(fieldType *reflect.StructField, fieldValue ref
 		return nil
 	}
 
-	for _, f := range r.formatters {
+	for tag, f := range r.formatters {
 		if err := f.Format(fieldType.Tag, fieldPtrValue); err != nil {
 			return err
 		}
+
+		if r.usage != nil {
+			if _, ok := fieldType.Tag.Lookup(tag); ok {
+				r.markUsed(ComponentFormatter, tag)
+			}
+		}
 	}
 
 	return nil
 }
 
-// parseStruct parses body from http request into a ptr.
-func (r *Roamer) parseBody(req *http.Request, ptr any) error {
-	if !r.hasDecoders || req.ContentLength == 0 || req.Method == http.MethodGet {
-		return nil
+// parseBody parses body from http request into a ptr.
+//
+// Returns body if it is preserved.
+func (r *Roamer) parseBody(req *http.Request, ptr any) ([]byte, error) {
+	if (!r.hasDecoders && !r.preserveBody) || req.ContentLength == 0 || req.Method == http.MethodGet {
+		return nil, nil
 	}
 
 	contentType := req.Header.Get("Content-Type")
@@ -192,17 +659,152 @@ func (r *Roamer) parseBody(req *http.Request, ptr any) error {
 	}
 
 	d, ok := r.decoders[contentType]
-	if !ok {
-		return nil
+	switch {
+	case ok:
+		if r.logger != nil {
+			r.logger.Debug("roamer: decoder selected", "content_type", contentType)
+		}
+	case r.defaultDecoder != nil:
+		d = r.defaultDecoder
+		if r.logger != nil {
+			r.logger.Debug("roamer: default decoder selected", "content_type", contentType)
+		}
+	case r.hasDecoders:
+		if r.logger != nil {
+			r.logger.Debug("roamer: decoder not found", "content_type", contentType)
+		}
+
+		return nil, errors.Wrapf(rerr.UnsupportedContentType, "`%s` for `%T`", contentType, ptr)
+	}
+
+	var canceled *contextReader
+	if ctx := req.Context(); ctx.Done() != nil && req.Body != nil {
+		canceled = newContextReader(ctx, req.Body)
+		req.Body = canceled
+
+		defer func() {
+			canceled.stop()
+			if req.Body == canceled {
+				req.Body = canceled.ReadCloser
+			}
+		}()
+	}
+
+	var decompressed *decompressingReader
+	if contentEncoding := req.Header.Get("Content-Encoding"); r.contentDecoding && len(contentEncoding) > 0 &&
+		req.Body != nil {
+		body, ok, err := newDecompressingReader(contentEncoding, req.Body)
+		if err != nil {
+			return nil, errors.WithStack(rerr.DecodeError{Err: errors.WithMessage(err, "decompress request body")})
+		}
+
+		if ok {
+			decompressed = body
+			req.Body = decompressed
+
+			defer func() {
+				if req.Body == decompressed {
+					req.Body = decompressed.body
+				}
+			}()
+		}
 	}
 
-	if err := d.Decode(req, ptr); err != nil {
-		return errors.WithStack(rerr.DecodeError{
+	var limited *limitedReader
+	if r.requestSizeLimit > 0 && req.Body != nil {
+		if req.ContentLength > r.requestSizeLimit {
+			return nil, errors.Wrapf(rerr.RequestTooLarge, "body of %d bytes exceeds limit of %d bytes",
+				req.ContentLength, r.requestSizeLimit)
+		}
+
+		limited = &limitedReader{ReadCloser: req.Body, left: r.requestSizeLimit}
+		req.Body = limited
+
+		defer func() {
+			if req.Body == limited {
+				req.Body = limited.ReadCloser
+			}
+		}()
+	}
+
+	var data []byte
+	if r.preserveBody && req.Body != nil {
+		var (
+			buf *bytes.Buffer
+			err error
+		)
+
+		if r.preserveBodyPool != nil {
+			buf = bodyBuffer(r.preserveBodyPool)
+			_, err = buf.ReadFrom(req.Body)
+			data = buf.Bytes()
+		} else {
+			data, err = io.ReadAll(req.Body)
+		}
+
+		if err != nil {
+			if buf != nil {
+				buf.Reset()
+				r.preserveBodyPool.Put(buf)
+			}
+
+			if canceled != nil && canceled.err != nil {
+				return nil, errors.WithStack(rerr.DecodeError{Err: errors.WithMessage(canceled.err, "read request body")})
+			}
+
+			if limited != nil && limited.exceeded {
+				return nil, errors.Wrapf(rerr.RequestTooLarge, "body exceeds limit of %d bytes", r.requestSizeLimit)
+			}
+
+			return nil, errors.Wrap(err, "read request body")
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(data))
+
+		defer func() {
+			if buf == nil {
+				req.Body = io.NopCloser(bytes.NewReader(data))
+				return
+			}
+
+			req.Body = &pooledBody{Reader: bytes.NewReader(data), buf: buf, pool: r.preserveBodyPool}
+		}()
+
+		if decompressed != nil {
+			// preserved body is decompressed.
+			req.Header.Del("Content-Encoding")
+			req.ContentLength = int64(len(data))
+		}
+	}
+
+	if d == nil {
+		return data, nil
+	}
+
+	if r.typeResolver != nil {
+		if err := r.resolveFields(req, ptr); err != nil {
+			return nil, err
+		}
+	}
+
+	err := r.decode(d, req, ptr)
+	if canceled != nil && canceled.err != nil {
+		return nil, errors.WithStack(rerr.DecodeError{
+			Err: errors.WithMessagef(canceled.err, "decode `%s` request body for `%T`", contentType, ptr),
+		})
+	}
+
+	if limited != nil && limited.exceeded {
+		return nil, errors.Wrapf(rerr.RequestTooLarge, "body exceeds limit of %d bytes", r.requestSizeLimit)
+	}
+
+	if err != nil {
+		return nil, errors.WithStack(rerr.DecodeError{
 			Err: errors.WithMessagef(err, "decode `%s` request body for `%T`", contentType, ptr),
 		})
 	}
 
-	return nil
+	return data, nil
 }
 
 // enableExperimentalFeatures enables experimental features.
@@ -6,13 +6,21 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/slipros/roamer/decoder"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/formatter"
 	"github.com/slipros/roamer/parser"
+	"github.com/slipros/roamer/value"
+	"github.com/stretchr/testify/require"
 )
 
 var errBigBad = errors.New("big bad error")
@@ -199,3 +207,1676 @@ func BenchmarkParse_With_Body_Header_Query_FastStructFieldParser(b *testing.B) {
 		}
 	}
 }
+
+func TestRoamer_Parse_QueryCatchAll(t *testing.T) {
+	type Data struct {
+		Filters map[string]string `query:"*"`
+		Limit   int               `query:"limit"`
+		Rest    url.Values        `query:"rest"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?limit=10&filter[name]=test&filter[age]=18", nil)
+	require.NoError(t, err)
+
+	r := NewRoamer(WithParsers(parser.NewQuery()))
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, 10, d.Limit)
+	require.Equal(t, map[string]string{"filter[name]": "test", "filter[age]": "18"}, d.Filters)
+	require.Empty(t, d.Rest)
+}
+
+func TestRoamer_Parse_QueryCatchAllSkipFilled(t *testing.T) {
+	type Data struct {
+		Filters map[string]string `query:"*"`
+		Limit   int               `query:"limit"`
+		Sort    string            `header:"X-Sort" query:"sort"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?limit=10&sort=name&filter=test", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Sort", "age")
+
+	r := NewRoamer(WithParsers(parser.NewHeader(), parser.NewQuery()), WithSkipFilled(true))
+
+	d := Data{Limit: 20}
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Filters: map[string]string{"filter": "test"}, Limit: 20, Sort: "age"}, d)
+}
+
+func TestRoamer_Parse_HeaderSplit(t *testing.T) {
+	type Data struct {
+		Tags    []string `header:"X-Tags"`
+		RawTags string   `header:"X-Tags"`
+		Limit   int      `header:"X-Limit"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+	require.NoError(t, err)
+	req.Header.Add("X-Tags", "a, b")
+	req.Header.Add("X-Tags", "c")
+	req.Header.Add("X-Limit", "10")
+
+	r := NewRoamer(WithParsers(parser.NewHeader(parser.WithHeaderSplit())))
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Tags: []string{"a", "b", "c"}, RawTags: "a, b", Limit: 10}, d)
+}
+
+func TestRoamer_Parse_Encoding(t *testing.T) {
+	type Data struct {
+		Signature []byte `header:"X-Signature" encoding:"hex"`
+		Payload   []byte `query:"payload" encoding:"base64"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?payload=aGVsbG8%3D", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Signature", "DEADbeef")
+
+	r := NewRoamer(WithParsers(parser.NewHeader(), parser.NewQuery()))
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Signature: []byte{0xde, 0xad, 0xbe, 0xef}, Payload: []byte("hello")}, d)
+
+	req.Header.Set("X-Signature", "abc")
+
+	d = Data{}
+	err = r.Parse(req, &d)
+	require.Error(t, err)
+}
+
+func TestRoamer_Parse_MaxItems(t *testing.T) {
+	type Data struct {
+		IDs []string `query:"ids,maxitems=3"`
+	}
+
+	r := NewRoamer(WithParsers(parser.NewQuery()))
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?ids=1,2,3", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "3"}, d.IDs)
+
+	req, err = http.NewRequest(http.MethodGet, "test.com?ids=1,2,3,4", nil)
+	require.NoError(t, err)
+
+	d = Data{}
+	err = r.Parse(req, &d)
+	require.ErrorIs(t, err, rerr.TooManyItems)
+	require.Empty(t, d.IDs)
+}
+
+func TestRoamer_Parse_JSONLD(t *testing.T) {
+	type Data struct {
+		Type string `json:"@type"`
+		Name string `json:"name"`
+	}
+
+	r := NewRoamer(WithDecoders(decoder.NewJSON(), decoder.NewJSONLD()))
+
+	for _, contentType := range []string{decoder.ContentTypeJSON, decoder.ContentTypeJSONLD + "; charset=utf-8"} {
+		body := `{"@type":"Person","name":"Jane Doe"}`
+		req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+
+		var d Data
+		err = r.Parse(req, &d)
+		require.NoError(t, err)
+		require.Equal(t, Data{Type: "Person", Name: "Jane Doe"}, d, contentType)
+	}
+}
+
+func TestRoamer_Parse_MetaBodyLength(t *testing.T) {
+	type Data struct {
+		Name      string `json:"name"`
+		BodyBytes int    `meta:"body_length"`
+	}
+
+	body := `{"name":"test"}`
+	req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+	originalBody := req.Body
+
+	r := NewRoamer(
+		WithDecoders(decoder.NewJSON()),
+		WithParsers(parser.NewMeta()),
+	)
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Name: "test", BodyBytes: len(body)}, d)
+	require.Equal(t, originalBody, req.Body, "request body is restored")
+}
+
+func TestRoamer_ParseBody_ParseParsers(t *testing.T) {
+	type Data struct {
+		Name  string `json:"name"`
+		Limit int    `query:"limit"`
+		Agent string `header:"User-Agent" string:"trim_space"`
+	}
+
+	newRequest := func(t *testing.T) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com?limit=10", strings.NewReader(`{"name":"test"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+		req.Header.Set("User-Agent", " agent ")
+
+		return req
+	}
+
+	r := NewRoamer(
+		WithDecoders(decoder.NewJSON()),
+		WithParsers(parser.NewQuery(), parser.NewHeader()),
+		WithFormatters(formatter.NewString()),
+	)
+
+	var d Data
+	err := r.ParseBody(newRequest(t), &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Name: "test"}, d)
+
+	d = Data{}
+	err = r.ParseParsers(newRequest(t), &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Limit: 10, Agent: "agent"}, d)
+
+	d = Data{}
+	err = r.Parse(newRequest(t), &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Name: "test", Limit: 10, Agent: "agent"}, d)
+
+	var sl []string
+	err = r.ParseParsers(newRequest(t), &sl)
+	require.ErrorIs(t, err, rerr.NotSupported)
+
+	err = r.ParseBody(newRequest(t), nil)
+	require.ErrorIs(t, err, rerr.NilValue)
+
+	err = r.ParseParsers(newRequest(t), d)
+	require.ErrorIs(t, err, rerr.NotPtr)
+}
+
+func TestRoamer_Parse_InvalidDestination(t *testing.T) {
+	type Data struct {
+		Name string `json:"name"`
+		ID   int    `query:"id"`
+	}
+
+	var (
+		nilData  *Data
+		nilSlice *[]Data
+		number   int
+	)
+
+	tests := []struct {
+		name    string
+		ptr     any
+		wantErr error
+	}{
+		{
+			name:    "nil",
+			ptr:     nil,
+			wantErr: rerr.NilValue,
+		},
+		{
+			name:    "typed nil pointer to struct",
+			ptr:     nilData,
+			wantErr: rerr.NilValue,
+		},
+		{
+			name:    "typed nil pointer to slice",
+			ptr:     nilSlice,
+			wantErr: rerr.NilValue,
+		},
+		{
+			name:    "not a pointer",
+			ptr:     Data{},
+			wantErr: rerr.NotPtr,
+		},
+		{
+			name:    "pointer to not a struct",
+			ptr:     &number,
+			wantErr: rerr.NotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRoamer(WithDecoders(decoder.NewJSON()), WithParsers(parser.NewQuery()))
+
+			newRequest := func(t *testing.T) *http.Request {
+				t.Helper()
+
+				req, err := http.NewRequest(http.MethodPost, "test.com?id=1", strings.NewReader(`{"name":"test"}`))
+				require.NoError(t, err)
+				req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+				return req
+			}
+
+			require.ErrorIs(t, r.Parse(newRequest(t), tt.ptr), tt.wantErr)
+			require.ErrorIs(t, r.ParseParsers(newRequest(t), tt.ptr), tt.wantErr)
+			require.ErrorIs(t, r.ParseBody(newRequest(t), tt.ptr), tt.wantErr)
+		})
+	}
+
+	var handled error
+	handler := Middleware[int](NewRoamer())(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var n int
+		handled = ParsedDataFromContext(r.Context(), &n)
+	}))
+
+	req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.ErrorIs(t, handled, rerr.NotSupported)
+}
+
+func TestRoamer_Parse_QueryDuplicatePolicy(t *testing.T) {
+	type Data struct {
+		ID int `query:"id"`
+	}
+
+	tests := []struct {
+		name   string
+		policy parser.DuplicatePolicy
+		want   int
+	}{
+		{
+			name:   "first",
+			policy: parser.DuplicateFirst,
+			want:   1,
+		},
+		{
+			name:   "last",
+			policy: parser.DuplicateLast,
+			want:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?id=1&id=2", nil)
+			require.NoError(t, err)
+
+			r := NewRoamer(WithParsers(parser.NewQuery(parser.WithDuplicatePolicy(tt.policy))))
+
+			var d Data
+			err = r.Parse(req, &d)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d.ID)
+		})
+	}
+}
+
+func TestRoamer_Parse_SkipEmpty(t *testing.T) {
+	type Data struct {
+		Name    string `query:"name,skipempty"`
+		Surname string `query:"surname"`
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  Data
+	}{
+		{
+			name:  "absent",
+			query: "",
+			want:  Data{Name: "default", Surname: "default"},
+		},
+		{
+			name:  "empty",
+			query: "name=&surname=",
+			want:  Data{Name: "default", Surname: ""},
+		},
+		{
+			name:  "present",
+			query: "name=new&surname=new",
+			want:  Data{Name: "new", Surname: "new"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPatch, "test.com?"+tt.query, nil)
+			require.NoError(t, err)
+
+			r := NewRoamer(WithParsers(parser.NewQuery()), WithSkipFilled(false))
+
+			d := Data{Name: "default", Surname: "default"}
+			err = r.Parse(req, &d)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestRoamer_Parse_MapKeyCase(t *testing.T) {
+	type Data struct {
+		Filters map[string]string `query:"*,keycase=lower"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?Filter[Name]=test&FILTER[AGE]=18", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"filter[name]": "test", "filter[age]": "18"}, d.Filters)
+}
+
+func TestRoamer_Parse_PointerSliceAndMap(t *testing.T) {
+	type Data struct {
+		Tags    *[]string          `query:"tags"`
+		IDs     *[]int             `query:"ids"`
+		Filters *map[string]string `query:"*"`
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		check   func(t *testing.T, d Data)
+		wantErr bool
+	}{
+		{
+			name:  "present",
+			query: "tags=a,b&ids=1,2&sort=asc",
+			check: func(t *testing.T, d Data) {
+				require.NotNil(t, d.Tags)
+				require.Equal(t, []string{"a", "b"}, *d.Tags)
+				require.NotNil(t, d.IDs)
+				require.Equal(t, []int{1, 2}, *d.IDs)
+				require.NotNil(t, d.Filters)
+				require.Equal(t, map[string]string{"sort": "asc"}, *d.Filters)
+			},
+		},
+		{
+			name:  "absent",
+			query: "",
+			check: func(t *testing.T, d Data) {
+				require.Nil(t, d.Tags)
+				require.Nil(t, d.IDs)
+				require.Nil(t, d.Filters)
+			},
+		},
+		{
+			name:    "invalid element",
+			query:   "ids=1,a",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?"+tt.query, nil)
+			require.NoError(t, err)
+
+			var d Data
+			err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &d)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Nil(t, d.IDs)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.check(t, d)
+		})
+	}
+}
+
+func TestRoamer_Parse_Link(t *testing.T) {
+	type Data struct {
+		Links []parser.LinkValue `link:"Link"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+	require.NoError(t, err)
+	req.Header.Add("Link", `<https://example.com/?page=2>; rel="next"; title="Next"`)
+	req.Header.Add("Link", `<https://example.com/?page=1>; rel="prev"`)
+
+	var d Data
+	err = NewRoamer(WithParsers(parser.NewLink())).Parse(req, &d)
+	require.NoError(t, err)
+	require.Len(t, d.Links, 2)
+	require.Equal(t, "next", d.Links[0].Rel)
+	require.Equal(t, "Next", d.Links[0].Title)
+	require.Equal(t, "https://example.com/?page=1", d.Links[1].URI)
+}
+
+// sharedToken is a token decoded once and shared between parsers.
+type sharedToken struct {
+	Subject string
+	Role    string
+}
+
+var sharedTokenKey = parser.NewArtifactKey[sharedToken]("token")
+
+// tokenParser parses fields of token from Authorization header.
+type tokenParser struct {
+	tag     string
+	decodes *int
+}
+
+func (p *tokenParser) Parse(r *http.Request, tag reflect.StructTag, cache parser.Cache) (any, bool) {
+	tagValue, ok := tag.Lookup(p.tag)
+	if !ok {
+		return nil, false
+	}
+
+	token, ok := parser.LoadOrParseArtifact(cache, sharedTokenKey, func() (sharedToken, bool) {
+		*p.decodes++
+
+		subject, role, found := strings.Cut(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), ":")
+		return sharedToken{Subject: subject, Role: role}, found
+	})
+	if !ok {
+		return nil, false
+	}
+
+	switch tagValue {
+	case "sub":
+		return token.Subject, true
+	case "role":
+		return token.Role, true
+	}
+
+	return nil, false
+}
+
+func (p *tokenParser) Tag() string {
+	return p.tag
+}
+
+func TestRoamer_Parse_SharedArtifact(t *testing.T) {
+	type Data struct {
+		Subject string `jwt:"sub"`
+		Role    string `claims:"role"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer user:admin")
+
+	decodes := 0
+	r := NewRoamer(WithParsers(
+		&tokenParser{tag: "jwt", decodes: &decodes},
+		&tokenParser{tag: "claims", decodes: &decodes},
+	))
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Subject: "user", Role: "admin"}, d)
+	require.Equal(t, 1, decodes)
+}
+
+// siblingParser parses header into a field only if sibling field is empty, e.g. `sibling:"X-Email,Email"`.
+type siblingParser struct{}
+
+func (p *siblingParser) Parse(_ *http.Request, _ reflect.StructTag, _ parser.Cache) (any, bool) {
+	return nil, false
+}
+
+func (p *siblingParser) ParseWithDestination(
+	r *http.Request,
+	tag reflect.StructTag,
+	_ parser.Cache,
+	dst reflect.Value,
+) (any, bool) {
+	tagValue, ok := tag.Lookup(p.Tag())
+	if !ok {
+		return nil, false
+	}
+
+	header, sibling, _ := strings.Cut(tagValue, ",")
+	if field := dst.FieldByName(sibling); field.IsValid() && !field.IsZero() {
+		return nil, false
+	}
+
+	v := r.Header.Get(header)
+	return v, len(v) > 0
+}
+
+func (p *siblingParser) Tag() string {
+	return "sibling"
+}
+
+func TestRoamer_Parse_DestinationParser(t *testing.T) {
+	type Data struct {
+		Email        string `json:"email"`
+		ContactEmail string `sibling:"X-Email,Email"`
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want Data
+	}{
+		{
+			name: "sibling is filled by body",
+			body: `{"email":"body@test.com"}`,
+			want: Data{Email: "body@test.com"},
+		},
+		{
+			name: "sibling is empty",
+			body: `{}`,
+			want: Data{ContactEmail: "header@test.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+			req.Header.Set("X-Email", "header@test.com")
+
+			r := NewRoamer(WithDecoders(decoder.NewJSON()), WithParsers(&siblingParser{}))
+
+			var d Data
+			err = r.Parse(req, &d)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestRoamer_Parse_NullLiteral(t *testing.T) {
+	type Data struct {
+		MiddleName *string `query:"middle_name,null"`
+		Nickname   *string `query:"nickname,null=none"`
+		Age        *int    `query:"age,null"`
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		check func(t *testing.T, d Data)
+	}{
+		{
+			name:  "null literal",
+			query: "middle_name=null&nickname=none&age=null",
+			check: func(t *testing.T, d Data) {
+				require.Nil(t, d.MiddleName)
+				require.Nil(t, d.Nickname)
+				require.Nil(t, d.Age)
+			},
+		},
+		{
+			name:  "normal values",
+			query: "middle_name=john&nickname=null&age=18",
+			check: func(t *testing.T, d Data) {
+				require.NotNil(t, d.MiddleName)
+				require.Equal(t, "john", *d.MiddleName)
+				require.NotNil(t, d.Nickname)
+				require.Equal(t, "null", *d.Nickname)
+				require.NotNil(t, d.Age)
+				require.Equal(t, 18, *d.Age)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?"+tt.query, nil)
+			require.NoError(t, err)
+
+			var d Data
+			err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &d)
+			require.NoError(t, err)
+			tt.check(t, d)
+		})
+	}
+}
+
+func TestRoamer_Parse_QueryIndexedArrays(t *testing.T) {
+	type Data struct {
+		Items []string `query:"items"`
+		IDs   []int    `query:"ids"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?items[2]=c&items[0]=a&ids[1]=2&ids[0]=1", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = NewRoamer(WithParsers(parser.NewQuery(parser.WithIndexedArrays()))).Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Items: []string{"a", "", "c"}, IDs: []int{1, 2}}, d)
+}
+
+func TestRoamer_With(t *testing.T) {
+	type Data struct {
+		Name string `json:"name" xml:"name"`
+		ID   int    `query:"id"`
+	}
+
+	parent := NewRoamer(WithDecoders(decoder.NewJSON()), WithParsers(parser.NewQuery()))
+	child := parent.With(WithDecoders(decoder.NewXML()), WithSkipFilled(false))
+
+	require.Contains(t, child.decoders, decoder.ContentTypeXML)
+	require.Contains(t, child.decoders, decoder.ContentTypeJSON)
+	require.NotContains(t, parent.decoders, decoder.ContentTypeXML)
+	require.False(t, child.skipFilled)
+	require.True(t, parent.skipFilled)
+
+	newRequest := func(t *testing.T) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com?id=1", strings.NewReader(`<Data><name>test</name></Data>`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", decoder.ContentTypeXML)
+
+		return req
+	}
+
+	var d Data
+	err := child.Parse(newRequest(t), &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Name: "test", ID: 1}, d)
+
+	d = Data{}
+	err = parent.Parse(newRequest(t), &d)
+	require.ErrorIs(t, err, rerr.UnsupportedContentType)
+	require.Empty(t, d.Name)
+}
+
+func TestRoamer_Parse_Sort(t *testing.T) {
+	type Data struct {
+		Sort []parser.SortSpec `sort:""`
+	}
+
+	r := NewRoamer(WithParsers(parser.NewSort("sort", parser.WithSortFields("name", "created_at"))))
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?sort=-created_at,name", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, []parser.SortSpec{
+		{Field: "created_at", Order: parser.SortDesc},
+		{Field: "name", Order: parser.SortAsc},
+	}, d.Sort)
+
+	req, err = http.NewRequest(http.MethodGet, "test.com?sort=password", nil)
+	require.NoError(t, err)
+
+	d = Data{}
+	err = r.Parse(req, &d)
+	require.ErrorIs(t, err, rerr.NotAllowed)
+
+	parseErr, ok := IsParseError(err)
+	require.True(t, ok)
+	require.Equal(t, "Sort", parseErr.Field)
+}
+
+func TestRoamer_Parse_CacheFactory(t *testing.T) {
+	type Data struct {
+		ID        int    `query:"id"`
+		Name      string `query:"name"`
+		UserAgent string `header:"User-Agent"`
+	}
+
+	var created, released int
+	r := NewRoamer(
+		WithParsers(parser.NewQuery(), parser.NewHeader()),
+		WithCacheFactory(func() parser.Cache {
+			created++
+			return make(parser.Cache)
+		}),
+		WithCacheRelease(func(cache parser.Cache) {
+			released++
+			require.NotEmpty(t, cache)
+			clear(cache)
+		}),
+	)
+
+	for range 2 {
+		req, err := http.NewRequest(http.MethodGet, "test.com?id=1&name=test", nil)
+		require.NoError(t, err)
+		req.Header.Set("User-Agent", "agent")
+
+		var d Data
+		err = r.Parse(req, &d)
+		require.NoError(t, err)
+		require.Equal(t, Data{ID: 1, Name: "test", UserAgent: "agent"}, d)
+	}
+
+	require.Equal(t, 2, created)
+	require.Equal(t, 2, released)
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?id=2", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = NewRoamer(WithParsers(parser.NewQuery()), WithCacheFactory(func() parser.Cache { return nil })).Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{ID: 2}, d)
+}
+
+func BenchmarkParse_CacheFactory(b *testing.B) {
+	type Data struct {
+		ID        int    `query:"id"`
+		Name      string `query:"name"`
+		UserAgent string `header:"User-Agent"`
+	}
+
+	req := http.Request{
+		Method: http.MethodGet,
+		URL:    &url.URL{RawQuery: "id=1&name=test"},
+		Header: http.Header{"User-Agent": {"agent"}},
+	}
+
+	pool := sync.Pool{
+		New: func() any {
+			return make(parser.Cache)
+		},
+	}
+
+	roamers := map[string]*Roamer{
+		"Default": NewRoamer(WithSkipFilled(false), WithParsers(parser.NewQuery(), parser.NewHeader())),
+		"Pooled": NewRoamer(
+			WithSkipFilled(false),
+			WithParsers(parser.NewQuery(), parser.NewHeader()),
+			WithCacheFactory(func() parser.Cache {
+				return pool.Get().(parser.Cache)
+			}),
+			WithCacheRelease(func(cache parser.Cache) {
+				clear(cache)
+				pool.Put(cache)
+			}),
+		),
+	}
+
+	for name, r := range roamers {
+		b.Run(name, func(b *testing.B) {
+			var d Data
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if err := r.Parse(&req, &d); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParse_StructFields(b *testing.B) {
+	type Data struct {
+		ID        int      `query:"id"`
+		Name      string   `query:"name"`
+		Page      int      `query:"page"`
+		Limit     int      `query:"limit"`
+		Sort      string   `query:"sort"`
+		Tags      []string `query:"tags"`
+		UserAgent string   `header:"User-Agent"`
+		RequestID string   `header:"X-Request-Id"`
+		internal  string
+		Untagged  string
+	}
+
+	req := http.Request{
+		Method: http.MethodGet,
+		URL:    &url.URL{RawQuery: "id=1&name=test&page=2&limit=10&sort=name&tags=a,b"},
+		Header: http.Header{"User-Agent": {"agent"}, "X-Request-Id": {"1"}},
+	}
+
+	r := NewRoamer(WithSkipFilled(false), WithParsers(parser.NewQuery(), parser.NewHeader()))
+
+	var d Data
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := r.Parse(&req, &d); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	_ = d.internal
+}
+
+type testCountryCode struct {
+	Code string
+}
+
+func TestRoamer_Parse_RegisteredConverter(t *testing.T) {
+	typ := reflect.TypeOf(testCountryCode{})
+	value.RegisterConverter(typ, func(field reflect.Value, raw string) error {
+		if len(raw) != 2 {
+			return errBigBad
+		}
+
+		field.Set(reflect.ValueOf(testCountryCode{Code: strings.ToUpper(raw)}))
+		return nil
+	})
+	t.Cleanup(func() {
+		value.RegisterConverter(typ, nil)
+	})
+
+	type Data struct {
+		Country   testCountryCode   `query:"country"`
+		Countries []testCountryCode `query:"countries"`
+	}
+
+	r := NewRoamer(WithParsers(parser.NewQuery()))
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?country=us&countries=de,fr", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{
+		Country:   testCountryCode{Code: "US"},
+		Countries: []testCountryCode{{Code: "DE"}, {Code: "FR"}},
+	}, d)
+
+	req, err = http.NewRequest(http.MethodGet, "test.com?country=usa", nil)
+	require.NoError(t, err)
+
+	d = Data{}
+	err = r.Parse(req, &d)
+	require.ErrorIs(t, err, errBigBad)
+}
+
+func TestRoamer_Parse_UnixTime(t *testing.T) {
+	type Data struct {
+		Auto    time.Time  `query:"auto"`
+		Seconds time.Time  `query:"seconds" time:"unix"`
+		Millis  *time.Time `query:"millis" time:"unix_ms"`
+	}
+
+	r := NewRoamer(WithParsers(parser.NewQuery()), WithFormatters(formatter.NewTime()))
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?auto=1700000000123&seconds=1700000000123&millis=1700000000", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, time.UnixMilli(1700000000123).UTC(), d.Auto)
+	require.Equal(t, time.Unix(1700000000123, 0).UTC(), d.Seconds)
+	require.NotNil(t, d.Millis)
+	require.Equal(t, time.UnixMilli(1700000000).UTC(), *d.Millis)
+
+	req, err = http.NewRequest(http.MethodGet, "test.com?seconds=2023-11-14T22:13:20Z", nil)
+	require.NoError(t, err)
+
+	d = Data{}
+	err = r.Parse(req, &d)
+	require.ErrorIs(t, err, rerr.NotSupported)
+
+	req, err = http.NewRequest(http.MethodGet, "test.com?seconds=1700000000123", nil)
+	require.NoError(t, err)
+
+	d = Data{}
+	err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, time.UnixMilli(1700000000123).UTC(), d.Seconds, "time tag is ignored without time formatter")
+}
+
+func TestRoamer_Parse_DecoderDispatch(t *testing.T) {
+	type Data struct {
+		Name string `json:"name" xml:"name"`
+	}
+
+	tests := []struct {
+		name        string
+		opts        []OptionsFunc
+		contentType string
+		body        string
+		want        Data
+		wantErr     error
+	}{
+		{
+			name:        "Matched content type",
+			contentType: decoder.ContentTypeXML,
+			body:        `<Data><name>test</name></Data>`,
+			want:        Data{Name: "test"},
+		},
+		{
+			name:        "Matched content type with params",
+			contentType: decoder.ContentTypeJSON + "; charset=utf-8",
+			body:        `{"name":"test"}`,
+			want:        Data{Name: "test"},
+		},
+		{
+			name:    "Missing content type",
+			body:    `{"name":"test"}`,
+			wantErr: rerr.UnsupportedContentType,
+		},
+		{
+			name:        "Unmatched content type",
+			contentType: "application/x-www-form-urlencoded",
+			body:        `name=test`,
+			wantErr:     rerr.UnsupportedContentType,
+		},
+		{
+			name: "Missing content type with default decoder",
+			opts: []OptionsFunc{WithDefaultDecoder(decoder.NewJSON())},
+			body: `{"name":"test"}`,
+			want: Data{Name: "test"},
+		},
+		{
+			name:        "Unmatched content type with default decoder",
+			opts:        []OptionsFunc{WithDefaultDecoder(decoder.NewJSON())},
+			contentType: "application/x-www-form-urlencoded",
+			body:        `{"name":"test"}`,
+			want:        Data{Name: "test"},
+		},
+		{
+			name:        "Matched content type takes precedence over default decoder",
+			opts:        []OptionsFunc{WithDefaultDecoder(decoder.NewJSON())},
+			contentType: decoder.ContentTypeXML,
+			body:        `<Data><name>test</name></Data>`,
+			want:        Data{Name: "test"},
+		},
+		{
+			name:        "Empty body",
+			contentType: "text/plain",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			if len(tt.contentType) > 0 {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			r := NewRoamer(append([]OptionsFunc{WithDecoders(decoder.NewJSON(), decoder.NewXML())}, tt.opts...)...)
+
+			var d Data
+			err = r.Parse(req, &d)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "test.com", strings.NewReader(`{"name":"test"}`))
+	require.NoError(t, err)
+
+	var d Data
+	err = NewRoamer(WithDefaultDecoder(decoder.NewJSON())).Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Name: "test"}, d, "default decoder is used without other decoders")
+}
+
+func TestRoamer_Parse_TopLevelArray(t *testing.T) {
+	type Item struct {
+		ID   int    `json:"id" query:"id"`
+		Name string `json:"name" header:"X-Name" string:"upper"`
+	}
+
+	r := NewRoamer(
+		WithDecoders(decoder.NewJSON()),
+		WithParsers(parser.NewQuery(), parser.NewHeader()),
+		WithFormatters(formatter.NewString()),
+	)
+
+	newRequest := func(t *testing.T, body string) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com?id=100", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+		req.Header.Set("X-Name", "header")
+
+		return req
+	}
+
+	t.Run("Slice of structs", func(t *testing.T) {
+		var items []Item
+		err := r.Parse(newRequest(t, `[{"id":1,"name":"first"},{"id":2,"name":"second"}]`), &items)
+		require.NoError(t, err)
+		require.Equal(t, []Item{{ID: 1, Name: "first"}, {ID: 2, Name: "second"}}, items,
+			"parsers and formatters are skipped")
+	})
+
+	t.Run("Slice of pointers", func(t *testing.T) {
+		var items []*Item
+		err := r.Parse(newRequest(t, `[{"id":1}]`), &items)
+		require.NoError(t, err)
+		require.Equal(t, []*Item{{ID: 1}}, items)
+	})
+
+	t.Run("Empty array", func(t *testing.T) {
+		items := []Item{{ID: 1}}
+		err := r.Parse(newRequest(t, `[]`), &items)
+		require.NoError(t, err)
+		require.Empty(t, items)
+	})
+
+	t.Run("Object into slice", func(t *testing.T) {
+		var items []Item
+		err := r.Parse(newRequest(t, `{"id":1}`), &items)
+		require.Error(t, err)
+	})
+}
+
+func TestRoamer_Parse_Validator(t *testing.T) {
+	type Data struct {
+		Name string `query:"name"`
+	}
+
+	errNameRequired := errors.New("name is required")
+
+	var validated []any
+	r := NewRoamer(
+		WithParsers(parser.NewQuery()),
+		WithValidator(func(ptr any) error {
+			validated = append(validated, ptr)
+
+			if d, ok := ptr.(*Data); ok && len(d.Name) == 0 {
+				return errNameRequired
+			}
+
+			return nil
+		}),
+	)
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?name=test", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, []any{&d}, validated)
+
+	req, err = http.NewRequest(http.MethodGet, "test.com", nil)
+	require.NoError(t, err)
+
+	d = Data{}
+	err = r.Parse(req, &d)
+	require.ErrorIs(t, err, errNameRequired)
+
+	_, ok := IsValidationError(err)
+	require.True(t, ok)
+
+	var handled error
+	handler := Middleware[Data](r)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var d Data
+		handled = ParsedDataFromContext(r.Context(), &d)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.ErrorIs(t, handled, errNameRequired)
+}
+
+func TestRoamer_Parse_HeaderAll(t *testing.T) {
+	type Data struct {
+		Agent   string              `header:"User-Agent"`
+		Headers http.Header         `header:"*"`
+		Raw     map[string][]string `header:"*"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "agent")
+	req.Header.Add("X-Forwarded-For", "10.0.0.1")
+	req.Header.Add("X-Forwarded-For", "10.0.0.2")
+
+	var d Data
+	err = NewRoamer(WithParsers(parser.NewHeader())).Parse(req, &d)
+	require.NoError(t, err)
+
+	want := http.Header{
+		"User-Agent":      {"agent"},
+		"X-Forwarded-For": {"10.0.0.1", "10.0.0.2"},
+	}
+	require.Equal(t, "agent", d.Agent)
+	require.Equal(t, want, d.Headers)
+	require.Equal(t, map[string][]string(want), d.Raw)
+
+	d.Headers.Add("X-Forwarded-For", "10.0.0.3")
+	require.Len(t, req.Header.Values("X-Forwarded-For"), 2, "field receives a copy of headers")
+}
+
+func TestRoamer_Parse_RawMessage(t *testing.T) {
+	type Data struct {
+		Filter  json.RawMessage `query:"filter"`
+		Payload json.RawMessage `json:"payload"`
+	}
+
+	query := url.Values{}
+	query.Set("filter", `{"status":["new","done"],"limit":10}`)
+
+	req, err := http.NewRequest(http.MethodPost, "test.com?"+query.Encode(), strings.NewReader(`{"payload":{"id":1}}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+	var d Data
+	err = NewRoamer(WithDecoders(decoder.NewJSON()), WithParsers(parser.NewQuery())).Parse(req, &d)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"status":["new","done"],"limit":10}`, string(d.Filter))
+	require.Equal(t, json.RawMessage(`{"id":1}`), d.Payload)
+
+	req, err = http.NewRequest(http.MethodGet, "test.com?filter=%7Bbroken", nil)
+	require.NoError(t, err)
+
+	var broken Data
+	err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &broken)
+	require.ErrorIs(t, err, rerr.InvalidFormat)
+}
+
+func TestRoamer_Parse_DoubleEncodedJSON(t *testing.T) {
+	type Event struct {
+		Type string `json:"type"`
+		ID   int    `json:"id"`
+	}
+
+	type Webhook struct {
+		Source string `json:"source"`
+		Event  Event  `json:"event" decode:"json"`
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "test.com",
+		strings.NewReader(`{"source":"queue","event":"{\"type\":\"created\",\"id\":7}"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+	var w Webhook
+	err = NewRoamer(WithDecoders(decoder.NewJSON()), WithStrictTags()).Parse(req, &w)
+	require.NoError(t, err)
+	require.Equal(t, Webhook{Source: "queue", Event: Event{Type: "created", ID: 7}}, w)
+}
+
+func TestRoamer_Parse_Base64JSON(t *testing.T) {
+	type State struct {
+		Redirect string `json:"redirect"`
+	}
+
+	type Data struct {
+		State State `query:"state,base64json"`
+	}
+
+	newRequest := func(t *testing.T, state string) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, "test.com?state="+state, nil)
+		require.NoError(t, err)
+
+		return req
+	}
+
+	r := NewRoamer(WithParsers(parser.NewQuery()))
+
+	var d Data
+	err := r.Parse(newRequest(t, "eyJyZWRpcmVjdCI6Ii9ob21lIn0"), &d)
+	require.NoError(t, err)
+	require.Equal(t, State{Redirect: "/home"}, d.State)
+
+	err = r.Parse(newRequest(t, "eyJyZWRpcmVjdCI6Ii9ob21lIn0*"), &Data{})
+	require.ErrorIs(t, err, rerr.InvalidFormat)
+
+	err = r.Parse(newRequest(t, "eyJyZWRpcmVjdCI6"), &Data{})
+	_, ok := IsDecodeError(err)
+	require.True(t, ok)
+}
+
+func TestRoamer_Parse_CacheControl(t *testing.T) {
+	type CacheControl struct {
+		NoCache bool `cachecontrol:"no-cache"`
+		NoStore bool `cachecontrol:"no-store"`
+		MaxAge  int  `cachecontrol:"max-age"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+	require.NoError(t, err)
+	req.Header.Set("Cache-Control", "no-cache, max-age=60")
+
+	var cc CacheControl
+	err = NewRoamer(WithParsers(parser.NewCacheControl())).Parse(req, &cc)
+	require.NoError(t, err)
+	require.Equal(t, CacheControl{NoCache: true, MaxAge: 60}, cc)
+}
+
+func TestRoamer_Parse_Digest(t *testing.T) {
+	type Digest struct {
+		Username string            `digest:"username"`
+		Realm    string            `digest:"realm"`
+		Nonce    string            `digest:"nonce"`
+		Response string            `digest:"response"`
+		Params   map[string]string `digest:"*"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", `Digest username="bob", realm="api", nonce="dcd98b", uri="/", response="6629fae4"`)
+
+	var d Digest
+	err = NewRoamer(WithParsers(parser.NewDigest())).Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Digest{
+		Username: "bob",
+		Realm:    "api",
+		Nonce:    "dcd98b",
+		Response: "6629fae4",
+		Params: map[string]string{
+			"username": "bob",
+			"realm":    "api",
+			"nonce":    "dcd98b",
+			"uri":      "/",
+			"response": "6629fae4",
+		},
+	}, d)
+}
+
+func TestRoamer_Parse_Traceparent(t *testing.T) {
+	type Trace struct {
+		TraceID string `traceparent:"trace_id"`
+		SpanID  string `traceparent:"span_id"`
+		Flags   string `traceparent:"flags"`
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		want   Trace
+	}{
+		{
+			name:   "Valid",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			want: Trace{
+				TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+				SpanID:  "00f067aa0ba902b7",
+				Flags:   "01",
+			},
+		},
+		{
+			name:   "Malformed",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-xyz-01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+			require.NoError(t, err)
+			req.Header.Set("traceparent", tt.header)
+
+			var trace Trace
+			err = NewRoamer(WithParsers(parser.NewTraceparent())).Parse(req, &trace)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, trace)
+		})
+	}
+}
+
+func TestRoamer_Parse_QueryOverride(t *testing.T) {
+	type Data struct {
+		Limit  int    `json:"limit" query:"limit,override"`
+		Offset int    `json:"offset" query:"offset"`
+		Sort   string `json:"sort" query:"sort,override"`
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		body  string
+		want  Data
+	}{
+		{
+			name:  "query overrides body",
+			query: "limit=50&sort=name",
+			body:  `{"limit":10,"sort":"id"}`,
+			want:  Data{Limit: 50, Sort: "name"},
+		},
+		{
+			name: "body without query",
+			body: `{"limit":10,"offset":5,"sort":"id"}`,
+			want: Data{Limit: 10, Offset: 5, Sort: "id"},
+		},
+		{
+			name:  "query without body",
+			query: "limit=50&offset=5",
+			body:  `{}`,
+			want:  Data{Limit: 50, Offset: 5},
+		},
+		{
+			name:  "body wins without override",
+			query: "offset=20",
+			body:  `{"offset":5}`,
+			want:  Data{Offset: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "test.com?"+tt.query, strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+			var d Data
+			err = NewRoamer(WithDecoders(decoder.NewJSON()), WithParsers(parser.NewQuery())).Parse(req, &d)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestRoamer_Parse_ParsersOrder(t *testing.T) {
+	type Data struct {
+		Lang string `query:"lang" header:"Accept-Language" path:"lang"`
+	}
+
+	path := parser.NewPath(func(_ *http.Request, _ string) (string, bool) {
+		return "fr", true
+	})
+
+	tests := []struct {
+		name    string
+		parsers []Parser
+		want    string
+	}{
+		{
+			name:    "query first",
+			parsers: []Parser{parser.NewQuery(), parser.NewHeader(), path},
+			want:    "en",
+		},
+		{
+			name:    "header first",
+			parsers: []Parser{parser.NewHeader(), parser.NewQuery(), path},
+			want:    "de",
+		},
+		{
+			name:    "path first, replaced parser keeps position",
+			parsers: []Parser{path, parser.NewHeader(), parser.NewQuery(), path},
+			want:    "fr",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRoamer(WithParsers(tt.parsers...))
+
+			for range 20 {
+				req, err := http.NewRequest(http.MethodGet, "test.com?lang=en", nil)
+				require.NoError(t, err)
+				req.Header.Set("Accept-Language", "de")
+
+				var d Data
+				err = r.Parse(req, &d)
+				require.NoError(t, err)
+				require.Equal(t, tt.want, d.Lang)
+			}
+		})
+	}
+}
+
+func TestRoamer_Parse_NumericFormatter(t *testing.T) {
+	type Data struct {
+		Offset int     `query:"offset" numeric:"nonneg"`
+		Delta  float64 `query:"delta" numeric:"abs"`
+		Limit  uint    `query:"limit" numeric:"nonneg,max=100"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?offset=-10&delta=-0.25&limit=500", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = NewRoamer(WithParsers(parser.NewQuery()), WithFormatters(formatter.NewNumeric())).Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Offset: 0, Delta: 0.25, Limit: 100}, d)
+}
+
+func TestRoamer_Parse_NumericRelaxed(t *testing.T) {
+	type Data struct {
+		Count  int     `query:"count" numeric:"relaxed"`
+		Amount float64 `header:"X-Amount" numeric:"relaxed"`
+		Limit  *uint   `header:"X-Limit" numeric:"relaxed,max=5000"`
+	}
+
+	maxLimit, limit := uint(5000), uint(7)
+
+	tests := []struct {
+		name    string
+		query   string
+		amount  string
+		limit   string
+		want    Data
+		wantErr error
+	}{
+		{
+			name:   "Grouping separators",
+			query:  "count=1_000",
+			amount: "1,000,000.25",
+			limit:  "10,000",
+			want:   Data{Count: 1000, Amount: 1000000.25, Limit: &maxLimit},
+		},
+		{
+			name:   "Plain numbers",
+			query:  "count=-15",
+			amount: "0.5",
+			limit:  "7",
+			want:   Data{Count: -15, Amount: 0.5, Limit: &limit},
+		},
+		{
+			name:    "Malformed grouping",
+			query:   "count=1",
+			amount:  "1,00,0",
+			wantErr: rerr.InvalidFormat,
+		},
+		{
+			name:    "Decimal comma",
+			query:   "count=1",
+			amount:  "1,5",
+			wantErr: rerr.InvalidFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?"+tt.query, nil)
+			require.NoError(t, err)
+			req.Header.Set("X-Amount", tt.amount)
+			if len(tt.limit) > 0 {
+				req.Header.Set("X-Limit", tt.limit)
+			}
+
+			var d Data
+			err = NewRoamer(
+				WithParsers(parser.NewQuery(), parser.NewHeader()),
+				WithFormatters(formatter.NewNumeric()),
+			).Parse(req, &d)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?count=1_000", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &d)
+	require.Error(t, err, "numeric tag is ignored without numeric formatter")
+}
+
+func TestRoamer_Parse_KeyValueStruct(t *testing.T) {
+	type Options struct {
+		Color string `json:"color"`
+		Size  int    `json:"size"`
+	}
+
+	type Data struct {
+		Opts   Options  `query:"opts"`
+		Strict *Options `query:"strict,strict"`
+		Header Options  `header:"X-Options"`
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		want    Data
+		wantErr error
+	}{
+		{
+			name:  "Two fields",
+			query: "opts=color=red,size=10&strict=size=1",
+			want: Data{
+				Opts:   Options{Color: "red", Size: 10},
+				Strict: &Options{Size: 1},
+				Header: Options{Color: "blue", Size: 2},
+			},
+		},
+		{
+			name:  "Unknown key is ignored",
+			query: "opts=color=red,shape=round",
+			want: Data{
+				Opts:   Options{Color: "red"},
+				Header: Options{Color: "blue", Size: 2},
+			},
+		},
+		{
+			name:    "Unknown key in strict mode",
+			query:   "strict=color=red,shape=round",
+			wantErr: rerr.NotAllowed,
+		},
+		{
+			name:    "Unknown single key in strict mode",
+			query:   "strict=shape=round",
+			wantErr: rerr.NotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?"+tt.query, nil)
+			require.NoError(t, err)
+			req.Header.Set("X-Options", "color=blue,size=2")
+
+			var d Data
+			err = NewRoamer(WithParsers(parser.NewQuery(), parser.NewHeader())).Parse(req, &d)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestRoamer_Parse_SliceElementsFormatting(t *testing.T) {
+	type Data struct {
+		Tags    []string `query:"tags" string:"lower"`
+		Accepts []string `header:"X-Accept" string:"trim_space,upper"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?tags=Go,Rust,python", nil)
+	require.NoError(t, err)
+	req.Header.Add("X-Accept", " json")
+	req.Header.Add("X-Accept", "Xml")
+
+	var d Data
+	err = NewRoamer(
+		WithParsers(parser.NewQuery(), parser.NewHeader(parser.WithHeaderSplit())),
+		WithFormatters(formatter.NewString()),
+	).Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, []string{"go", "rust", "python"}, d.Tags)
+	require.Equal(t, []string{"JSON", "XML"}, d.Accepts)
+}
+
+func TestRoamer_Parse_QueryAutoSplit(t *testing.T) {
+	type Data struct {
+		IDs  []int    `query:"ids"`
+		Tags []string `query:"tags"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?ids=1|2|3&tags=go,rust", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = NewRoamer(WithParsers(parser.NewQuery(parser.WithAutoSplit('|', ',')))).Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{IDs: []int{1, 2, 3}, Tags: []string{"go", "rust"}}, d)
+}
+
+func TestRoamer_Parse_DottedMaps(t *testing.T) {
+	type Data struct {
+		Labels map[string]map[string]string             `query:"labels"`
+		Limits map[string]map[string]map[string]int     `query:"limits"`
+		Tags   map[string]map[string][]string           `query:"tags"`
+		Extra  map[string]any                           `query:"extra"`
+		Nested *map[string]map[string]map[string]string `query:"nested"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?labels.env.region=us&labels.env.zone=a"+
+		"&limits.api.read.rps=10&limits.api.write.rps=5&tags.lang.backend=go&tags.lang.backend=rust"+
+		"&extra.a.b=c&nested.x.y.z=1", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = NewRoamer(WithParsers(parser.NewQuery(parser.WithDottedMaps()))).Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{
+		Labels: map[string]map[string]string{"env": {"region": "us", "zone": "a"}},
+		Limits: map[string]map[string]map[string]int{"api": {"read": {"rps": 10}, "write": {"rps": 5}}},
+		Tags:   map[string]map[string][]string{"lang": {"backend": {"go", "rust"}}},
+		Extra:  map[string]any{"a": map[string]any{"b": "c"}},
+		Nested: &map[string]map[string]map[string]string{"x": {"y": {"z": "1"}}},
+	}, d)
+}
+
+func TestRoamer_ParseValue(t *testing.T) {
+	type Data struct {
+		Name string `json:"name"`
+		ID   int    `query:"id"`
+	}
+
+	r := NewRoamer(WithDecoders(decoder.NewJSON()), WithParsers(parser.NewQuery()))
+
+	newRequest := func(t *testing.T) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com?id=1", strings.NewReader(`{"name":"test"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+		return req
+	}
+
+	var d Data
+	err := r.ParseValue(newRequest(t), reflect.ValueOf(&d).Elem())
+	require.NoError(t, err)
+	require.Equal(t, Data{Name: "test", ID: 1}, d)
+
+	err = r.ParseValue(newRequest(t), reflect.ValueOf(Data{}))
+	require.ErrorIs(t, err, rerr.NotSettable)
+
+	err = r.ParseValue(newRequest(t), reflect.ValueOf(&d))
+	require.ErrorIs(t, err, rerr.NotSupported)
+
+	var s struct {
+		data Data
+	}
+
+	err = r.ParseValue(newRequest(t), reflect.ValueOf(&s).Elem().Field(0))
+	require.ErrorIs(t, err, rerr.NotSettable, "unexported field is not settable")
+}
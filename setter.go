@@ -0,0 +1,45 @@
+package roamer
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/value"
+)
+
+const (
+	// TagSetter setter tag, e.g. `setter:"SetEmail"`.
+	TagSetter = "setter"
+)
+
+var typeError = reflect.TypeOf((*error)(nil)).Elem()
+
+// callSetter passes parsed value into a setter method of struct instead of direct field assignment.
+//
+// Setter must accept exactly one argument and return nothing or an error.
+// Parsed value is converted into the type of the argument the same way as it is done for fields.
+func callSetter(ptr any, name string, parsedValue any) error {
+	method := reflect.ValueOf(ptr).MethodByName(name)
+	if !method.IsValid() {
+		return errors.Wrapf(rerr.NotSupported, "setter `%s` of `%T` not found", name, ptr)
+	}
+
+	methodType := method.Type()
+	if methodType.NumIn() != 1 || methodType.NumOut() > 1 ||
+		(methodType.NumOut() == 1 && methodType.Out(0) != typeError) {
+		return errors.Wrapf(rerr.NotSupported, "setter `%s` of `%T` signature `%s`", name, ptr, methodType)
+	}
+
+	arg := reflect.New(methodType.In(0)).Elem()
+	if err := value.Set(arg, parsedValue); err != nil {
+		return err
+	}
+
+	out := method.Call([]reflect.Value{arg})
+	if len(out) == 0 || out[0].IsNil() {
+		return nil
+	}
+
+	return out[0].Interface().(error)
+}
@@ -0,0 +1,94 @@
+package roamer
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+var errInvalidEmail = errors.New("invalid email")
+
+type setterData struct {
+	Email   string `query:"email" setter:"SetEmail"`
+	Age     int    `query:"age" setter:"SetAge"`
+	Unknown string `query:"unknown" setter:"SetUnknown"`
+	Invalid string `query:"invalid" setter:"SetInvalid"`
+}
+
+func (s *setterData) SetEmail(email string) error {
+	if !strings.Contains(email, "@") {
+		return errInvalidEmail
+	}
+
+	s.Email = strings.ToLower(email)
+	return nil
+}
+
+func (s *setterData) SetAge(age int) {
+	s.Age = age * 2
+}
+
+func (s *setterData) SetInvalid(_, _ string) {}
+
+func TestRoamer_Parse_Setter(t *testing.T) {
+	r := NewRoamer(WithParsers(parser.NewQuery()))
+
+	tests := []struct {
+		name    string
+		query   string
+		want    setterData
+		wantErr error
+	}{
+		{
+			name:  "setter validates value",
+			query: "email=Test@Test.com&age=10",
+			want:  setterData{Email: "test@test.com", Age: 20},
+		},
+		{
+			name:    "setter returns error",
+			query:   "email=test",
+			wantErr: errInvalidEmail,
+		},
+		{
+			name:    "setter not found",
+			query:   "unknown=test",
+			wantErr: rerr.NotSupported,
+		},
+		{
+			name:    "setter invalid signature",
+			query:   "invalid=test",
+			wantErr: rerr.NotSupported,
+		},
+		{
+			name:    "setter argument conversion error",
+			query:   "age=ten",
+			wantErr: strconv.ErrSyntax,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com?"+tt.query, nil)
+			require.NoError(t, err)
+
+			var d setterData
+			err = r.Parse(req, &d)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, d)
+				return
+			}
+
+			require.ErrorIs(t, err, tt.wantErr)
+
+			parseErr, ok := IsParseError(err)
+			require.True(t, ok)
+			require.NotEmpty(t, parseErr.Field)
+		})
+	}
+}
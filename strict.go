@@ -0,0 +1,153 @@
+package roamer
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+// roamerTags tags handled by roamer itself.
+var roamerTags = []string{
+	TagDefault,
+	TagRequired,
+	TagEncoding,
+	TagRange,
+	TagResolve,
+	TagOneOf,
+	TagFormat,
+	TagBool,
+	TagSetter,
+}
+
+// strictTagsCache results of tags check by reflect.Type.
+type strictTagsCache = sync.Map
+
+// knownTags returns tags handled by roamer, its parsers, decoders and formatters and allowed by WithStrictTags.
+func (r *Roamer) knownTags() map[string]struct{} {
+	known := make(map[string]struct{}, len(roamerTags)+len(r.parsers)+len(r.formatters)+len(r.strictAllowedTags))
+	for _, tag := range roamerTags {
+		known[tag] = struct{}{}
+	}
+
+	for tag := range r.parsers {
+		known[tag] = struct{}{}
+	}
+
+	for tag := range r.formatters {
+		known[tag] = struct{}{}
+	}
+
+	decoders := make([]Decoder, 0, len(r.decoders)+1)
+	for _, d := range r.decoders {
+		decoders = append(decoders, d)
+	}
+
+	if r.defaultDecoder != nil {
+		decoders = append(decoders, r.defaultDecoder)
+	}
+
+	for _, d := range decoders {
+		if td, ok := d.(TaggedDecoder); ok {
+			for _, tag := range td.Tags() {
+				known[tag] = struct{}{}
+			}
+		}
+	}
+
+	for _, tag := range r.strictAllowedTags {
+		known[tag] = struct{}{}
+	}
+
+	return known
+}
+
+// checkTags checks that tags of struct fields are handled by roamer or its components,
+// struct type is checked once and the result is cached.
+func (r *Roamer) checkTags(t reflect.Type) error {
+	if cached, ok := r.strictTagsCache.Load(t); ok {
+		if cached == nil {
+			return nil
+		}
+
+		return cached.(error)
+	}
+
+	known := r.knownTags()
+
+	var unknown []string
+	for i := range t.NumField() {
+		fieldType := t.Field(i)
+		for _, key := range tagKeys(fieldType.Tag) {
+			if _, ok := known[key]; !ok {
+				unknown = append(unknown, fieldType.Name+"."+key)
+			}
+		}
+	}
+
+	var err error
+	if len(unknown) > 0 {
+		err = errors.Wrapf(rerr.InvalidTag, "unknown tags of struct `%s`: %s", t, strings.Join(unknown, ", "))
+	}
+
+	r.strictTagsCache.Store(t, err)
+
+	return err
+}
+
+// tagKeys returns keys of struct tag in order, e.g. `json` and `query` of `json:"id" query:"id"`.
+//
+// Tag is parsed the same way as by reflect.StructTag.Lookup, parsing stops at malformed part.
+func tagKeys(tag reflect.StructTag) []string {
+	var keys []string
+	for tag != "" {
+		// skip leading space.
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		// scan to colon, a space, a quote or a control character is a syntax error.
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+
+		name := string(tag[:i])
+		tag = tag[i+1:]
+
+		// scan quoted string to find value.
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+
+		if i >= len(tag) {
+			break
+		}
+
+		if _, err := strconv.Unquote(string(tag[:i+1])); err != nil {
+			break
+		}
+
+		keys = append(keys, name)
+		tag = tag[i+1:]
+	}
+
+	return keys
+}
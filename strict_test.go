@@ -0,0 +1,117 @@
+package roamer
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/slipros/roamer/decoder"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/formatter"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoamer_Parse_StrictTags(t *testing.T) {
+	type Valid struct {
+		Name  string `json:"name" string:"trim_space" required:"true"`
+		Path  string `jsonpath:"user.name"`
+		ID    int    `query:"id" default:"1"`
+		Email string `header:"X-Email" validate:"email"`
+		Plain string
+	}
+
+	type Misspelled struct {
+		Name string `json:"name"`
+		ID   int    `quey:"id"`
+		Page int    `query:"page" defualt:"1"`
+	}
+
+	type XMLOnly struct {
+		Name string `xml:"name"`
+	}
+
+	newRequest := func(t *testing.T) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com?id=2&page=3", strings.NewReader(`{"name":"test"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+		return req
+	}
+
+	r := NewRoamer(
+		WithDecoders(decoder.NewJSON()),
+		WithParsers(parser.NewQuery(), parser.NewHeader()),
+		WithFormatters(formatter.NewString()),
+		WithStrictTags("validate"),
+	)
+
+	var v Valid
+	err := r.Parse(newRequest(t), &v)
+	require.NoError(t, err)
+	require.Equal(t, Valid{Name: "test", ID: 2}, v)
+
+	var m Misspelled
+	err = r.Parse(newRequest(t), &m)
+	require.ErrorIs(t, err, rerr.InvalidTag)
+	require.Contains(t, err.Error(), "ID.quey, Page.defualt")
+	require.Equal(t, Misspelled{}, m)
+
+	// result is cached.
+	err = r.Parse(newRequest(t), &m)
+	require.ErrorIs(t, err, rerr.InvalidTag)
+
+	err = r.Parse(newRequest(t), &XMLOnly{})
+	require.ErrorIs(t, err, rerr.InvalidTag)
+	require.Contains(t, err.Error(), "Name.xml")
+
+	err = r.With(WithDecoders(decoder.NewXML())).Parse(newRequest(t), &XMLOnly{})
+	require.NoError(t, err)
+
+	err = NewRoamer(
+		WithDecoders(decoder.NewJSON()),
+		WithParsers(parser.NewQuery()),
+	).Parse(newRequest(t), &Misspelled{})
+	require.NoError(t, err)
+}
+
+func TestTagKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  reflect.StructTag
+		want []string
+	}{
+		{
+			name: "empty",
+			tag:  ``,
+		},
+		{
+			name: "single",
+			tag:  `json:"name"`,
+			want: []string{"json"},
+		},
+		{
+			name: "several",
+			tag:  `json:"name,omitempty"  query:"name" string:"trim_space"`,
+			want: []string{"json", "query", "string"},
+		},
+		{
+			name: "escaped quote",
+			tag:  `default:"a\"b" query:"id"`,
+			want: []string{"default", "query"},
+		},
+		{
+			name: "malformed",
+			tag:  `json:"name" query`,
+			want: []string{"json"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tagKeys(tt.tag))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package roamer
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/slipros/exp"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/slipros/roamer/parser"
+)
+
+// structField field of a struct which can be filled by parsers.
+type structField struct {
+	index int
+	field reflect.StructField
+	// denied field is not filled from request, but its default and validation tags are applied.
+	denied bool
+}
+
+// structFieldsCache fields of struct types by reflect.Type.
+type structFieldsCache = sync.Map
+
+// structFields returns fields of struct which can be filled by parsers,
+// fields catching all values of a source are placed after the others,
+// so explicitly tagged fields take precedence over them.
+//
+// Fields are collected on first parse of a type and cached by roamer.
+func (r *Roamer) structFields(v *reflect.Value) ([]structField, error) {
+	t := v.Type()
+	if cached, ok := r.structFieldsCache.Load(t); ok {
+		return cached.([]structField), nil
+	}
+
+	fields := make([]structField, 0, t.NumField())
+
+	var deferred []structField
+	for i := range t.NumField() {
+		var fieldType reflect.StructField
+		if r.experimentalFastStructField {
+			ft, exists := exp.FastStructField(v, i)
+			if !exists {
+				// should never happen - anomaly.
+				return nil, errors.WithStack(rerr.FieldIndexOutOfBounds)
+			}
+
+			fieldType = ft
+		} else {
+			fieldType = t.Field(i)
+		}
+
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		if len(fieldType.Tag) == 0 {
+			if r.fieldNameMapper == nil || fieldType.Anonymous {
+				continue
+			}
+
+			fieldType.Tag = r.implicitTag(fieldType.Name)
+		}
+
+		field := structField{index: i, field: fieldType, denied: !r.isFieldAllowed(fieldType.Name)}
+		if r.isTagValueAll(fieldType.Tag) {
+			deferred = append(deferred, field)
+			continue
+		}
+
+		fields = append(fields, field)
+	}
+
+	fields = append(fields, deferred...)
+
+	cached, _ := r.structFieldsCache.LoadOrStore(t, fields)
+	return cached.([]structField), nil
+}
+
+// isTagValueAll reports whether field catches all values of a source by tag of a parser, e.g. `query:"*"`.
+func (r *Roamer) isTagValueAll(tag reflect.StructTag) bool {
+	for _, name := range r.parserTags {
+		tagValue, ok := tag.Lookup(name)
+		if !ok {
+			continue
+		}
+
+		if tagValue, _ = parser.SplitTagValue(tagValue); tagValue == parser.TagValueAll {
+			return true
+		}
+	}
+
+	return false
+}
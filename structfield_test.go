@@ -0,0 +1,105 @@
+package roamer
+
+import (
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoamer_Parse_StructFieldsCache(t *testing.T) {
+	type Data struct {
+		ID    int               `query:"id"`
+		Role  string            `query:"role"`
+		Rest  map[string]string `query:"*"`
+		Other string
+	}
+
+	newRequest := func(t *testing.T) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, "test.com?id=1&role=admin&page=2", nil)
+		require.NoError(t, err)
+
+		return req
+	}
+
+	r := NewRoamer(WithParsers(parser.NewQuery()))
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var d Data
+			if err := r.Parse(newRequest(t), &d); err != nil {
+				t.Error(err)
+				return
+			}
+
+			if d.ID != 1 || d.Role != "admin" || d.Rest["page"] != "2" {
+				t.Errorf("unexpected data %+v", d)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	v := reflect.ValueOf(&Data{}).Elem()
+	fields, err := r.structFields(&v)
+	require.NoError(t, err)
+	require.Len(t, fields, 3)
+	require.Equal(t, "Rest", fields[2].field.Name, "field catching all values is the last")
+
+	protected := r.With(WithProtectedFields("role"))
+
+	var d Data
+	err = protected.Parse(newRequest(t), &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{ID: 1, Rest: map[string]string{"page": "2"}}, d, "copy does not reuse fields of original")
+
+	d = Data{}
+	err = r.Parse(newRequest(t), &d)
+	require.NoError(t, err)
+	require.Equal(t, "admin", d.Role)
+}
+
+func TestRoamer_isTagValueAll(t *testing.T) {
+	r := NewRoamer(WithParsers(parser.NewQuery(), parser.NewHeader()))
+
+	tests := []struct {
+		name string
+		tag  reflect.StructTag
+		want bool
+	}{
+		{name: "query", tag: `query:"*"`, want: true},
+		{name: "with options", tag: `query:"*,keycase=lower"`, want: true},
+		{name: "header", tag: `json:"headers" header:"*"`, want: true},
+		{name: "key", tag: `query:"q"`},
+		{name: "other tag", tag: `query:"q" mask:"*"`},
+		{name: "unknown parser", tag: `example:"*"`},
+		{name: "key starting with star", tag: `query:"*q"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, r.isTagValueAll(tt.tag))
+		})
+	}
+
+	type Data struct {
+		Q    string            `query:"q" example:"*"`
+		Rest map[string]string `query:"*"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "test.com?q=1&page=2", nil)
+	require.NoError(t, err)
+
+	var d Data
+	err = r.Parse(req, &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Q: "1", Rest: map[string]string{"page": "2"}}, d)
+}
@@ -0,0 +1,36 @@
+package roamer
+
+import (
+	"net/http"
+	"time"
+)
+
+// RelativeTimeAnchor returns time which relative time values of request are added to,
+// e.g. `-2h` of `time:"relative"` field.
+type RelativeTimeAnchor = func(r *http.Request) time.Time
+
+// timeAnchor returns anchor time of relative time values of request.
+func (r *Roamer) timeAnchor(req *http.Request) time.Time {
+	if r.relativeTimeAnchor != nil {
+		return r.relativeTimeAnchor(req)
+	}
+
+	return AnchorNow(req)
+}
+
+// AnchorNow anchors relative time values to current time.
+func AnchorNow(_ *http.Request) time.Time {
+	return time.Now()
+}
+
+// AnchorDateHeader anchors relative time values to time of Date header of request,
+// current time is used if the header is missing or invalid.
+func AnchorDateHeader(r *http.Request) time.Time {
+	if date := r.Header.Get("Date"); len(date) > 0 {
+		if t, err := http.ParseTime(date); err == nil {
+			return t
+		}
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,162 @@
+package roamer
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/slipros/roamer/formatter"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoamer_Parse_RelativeTime(t *testing.T) {
+	type Data struct {
+		From time.Time  `query:"from" time:"relative"`
+		To   *time.Time `query:"to" time:"relative"`
+	}
+
+	date := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Date header", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "test.com?from=-2h&to=%2B30m", nil)
+		require.NoError(t, err)
+		req.Header.Set("Date", date.Format(http.TimeFormat))
+
+		r := NewRoamer(
+			WithParsers(parser.NewQuery()),
+			WithFormatters(formatter.NewTime()),
+			WithRelativeTimeAnchor(AnchorDateHeader),
+		)
+
+		var d Data
+		err = r.Parse(req, &d)
+		require.NoError(t, err)
+		require.True(t, date.Add(-2*time.Hour).Equal(d.From))
+		require.NotNil(t, d.To)
+		require.True(t, date.Add(30*time.Minute).Equal(*d.To))
+	})
+
+	t.Run("Without Date header", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "test.com?from=-2h", nil)
+		require.NoError(t, err)
+
+		r := NewRoamer(
+			WithParsers(parser.NewQuery()),
+			WithFormatters(formatter.NewTime()),
+			WithRelativeTimeAnchor(AnchorDateHeader),
+		)
+
+		before := time.Now()
+
+		var d Data
+		err = r.Parse(req, &d)
+		require.NoError(t, err)
+		require.WithinRange(t, d.From, before.Add(-2*time.Hour), time.Now().Add(-2*time.Hour))
+	})
+
+	t.Run("Default anchor ignores Date header", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "test.com?from=1h", nil)
+		require.NoError(t, err)
+		req.Header.Set("Date", date.Format(http.TimeFormat))
+
+		before := time.Now()
+
+		var d Data
+		err = NewRoamer(WithParsers(parser.NewQuery()), WithFormatters(formatter.NewTime())).Parse(req, &d)
+		require.NoError(t, err)
+		require.WithinRange(t, d.From, before.Add(time.Hour), time.Now().Add(time.Hour))
+	})
+
+	t.Run("Absolute time", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "test.com?from=2024-03-10T10:00:00Z", nil)
+		require.NoError(t, err)
+
+		var d Data
+		err = NewRoamer(WithParsers(parser.NewQuery()), WithFormatters(formatter.NewTime())).Parse(req, &d)
+		require.NoError(t, err)
+		require.True(t, date.Add(-2*time.Hour).Equal(d.From))
+	})
+
+	t.Run("Without time formatter", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "test.com?from=-2h", nil)
+		require.NoError(t, err)
+
+		var d Data
+		err = NewRoamer(WithParsers(parser.NewQuery())).Parse(req, &d)
+		require.Error(t, err, "time tag is ignored without time formatter")
+	})
+}
+
+func TestRoamer_Parse_RetryAfter(t *testing.T) {
+	type Data struct {
+		RetryAfter time.Time  `header:"Retry-After" time:"retry_after"`
+		Ptr        *time.Time `header:"Retry-After" time:"retry_after"`
+	}
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	clock := func(_ *http.Request) time.Time {
+		return now
+	}
+
+	tests := []struct {
+		name    string
+		header  string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:   "Seconds",
+			header: "120",
+			want:   now.Add(2 * time.Minute),
+		},
+		{
+			name:   "HTTP-date",
+			header: "Wed, 21 Oct 2015 07:28:00 GMT",
+			want:   time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC),
+		},
+		{
+			name:    "Invalid",
+			header:  "later",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+			require.NoError(t, err)
+			req.Header.Set("Retry-After", tt.header)
+
+			r := NewRoamer(
+				WithParsers(parser.NewHeader()),
+				WithFormatters(formatter.NewTime()),
+				WithRelativeTimeAnchor(clock),
+			)
+
+			var d Data
+			err = r.Parse(req, &d)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(d.RetryAfter))
+			require.NotNil(t, d.Ptr)
+			require.True(t, tt.want.Equal(*d.Ptr))
+		})
+	}
+}
+
+func TestAnchorDateHeader(t *testing.T) {
+	date := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	req, err := http.NewRequest(http.MethodGet, "test.com", nil)
+	require.NoError(t, err)
+	req.Header.Set("Date", date.Format(http.TimeFormat))
+	require.True(t, date.Equal(AnchorDateHeader(req)))
+
+	req.Header.Set("Date", "yesterday")
+	before := time.Now()
+	require.WithinRange(t, AnchorDateHeader(req), before, time.Now())
+}
@@ -0,0 +1,73 @@
+package roamer
+
+import (
+	"slices"
+	"sync"
+)
+
+const (
+	// ComponentDecoder prefix of decoder names reported by UnusedComponents, e.g. `decoder:application/json`.
+	ComponentDecoder = "decoder"
+	// ComponentParser prefix of parser names reported by UnusedComponents, e.g. `parser:query`.
+	ComponentParser = "parser"
+	// ComponentFormatter prefix of formatter names reported by UnusedComponents, e.g. `formatter:string`.
+	ComponentFormatter = "formatter"
+)
+
+// usageTracker names of used components.
+type usageTracker = sync.Map
+
+// componentName returns name of component reported by UnusedComponents.
+func componentName(kind, name string) string {
+	return kind + ":" + name
+}
+
+// markUsed marks component as used if usage tracking is enabled.
+func (r *Roamer) markUsed(kind, name string) {
+	if r.usage == nil {
+		return
+	}
+
+	r.usage.Store(componentName(kind, name), struct{}{})
+}
+
+// UnusedComponents returns sorted names of decoders, parsers and formatters which were not used
+// since creation of roamer, e.g. `decoder:application/xml`, nil is returned without WithUsageTracking option.
+//
+// Decoder is used when it decodes a body, parser when it returns a value for a field
+// and formatter when it formats a field with its tag.
+func (r *Roamer) UnusedComponents() []string {
+	if r.usage == nil {
+		return nil
+	}
+
+	var unused []string
+	add := func(kind, name string) {
+		n := componentName(kind, name)
+		if _, ok := r.usage.Load(n); ok || slices.Contains(unused, n) {
+			return
+		}
+
+		unused = append(unused, n)
+	}
+
+	for contentType := range r.decoders {
+		add(ComponentDecoder, contentType)
+	}
+
+	if r.defaultDecoder != nil {
+		add(ComponentDecoder, r.defaultDecoder.ContentType())
+	}
+
+	for _, tag := range r.parserTags {
+		add(ComponentParser, tag)
+	}
+
+	for tag := range r.formatters {
+		add(ComponentFormatter, tag)
+	}
+
+	slices.Sort(unused)
+
+	return unused
+}
@@ -0,0 +1,71 @@
+package roamer
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/slipros/roamer/decoder"
+	"github.com/slipros/roamer/formatter"
+	"github.com/slipros/roamer/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoamer_UnusedComponents(t *testing.T) {
+	type Data struct {
+		Name  string `json:"name" string:"trim_space"`
+		Page  int    `query:"page"`
+		Token string `header:"X-Token"`
+	}
+
+	newRequest := func(t *testing.T) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, "test.com?page=2", strings.NewReader(`{"name":" test "}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", decoder.ContentTypeJSON)
+
+		return req
+	}
+
+	r := NewRoamer(
+		WithDecoders(decoder.NewJSON(), decoder.NewXML()),
+		WithParsers(parser.NewQuery(), parser.NewHeader()),
+		WithFormatters(formatter.NewString(), formatter.NewTime()),
+		WithUsageTracking(),
+	)
+
+	require.Equal(t, []string{
+		"decoder:application/json",
+		"decoder:application/xml",
+		"formatter:string",
+		"formatter:time",
+		"parser:header",
+		"parser:query",
+	}, r.UnusedComponents())
+
+	var d Data
+	err := r.Parse(newRequest(t), &d)
+	require.NoError(t, err)
+	require.Equal(t, Data{Name: "test", Page: 2}, d)
+
+	require.Equal(t, []string{
+		"decoder:application/xml",
+		"formatter:time",
+		"parser:header",
+	}, r.UnusedComponents())
+
+	c := r.With(WithParsers(parser.NewCookie()))
+	require.Equal(t, []string{
+		"decoder:application/json",
+		"decoder:application/xml",
+		"formatter:string",
+		"formatter:time",
+		"parser:cookie",
+		"parser:header",
+		"parser:query",
+	}, c.UnusedComponents())
+	require.Len(t, r.UnusedComponents(), 3)
+
+	require.Nil(t, NewRoamer(WithDecoders(decoder.NewJSON())).UnusedComponents())
+}
@@ -0,0 +1,51 @@
+package value
+
+import (
+	"reflect"
+	"sync"
+)
+
+// ConverterFunc function setting raw string value into a field of registered type.
+type ConverterFunc = func(field reflect.Value, raw string) error
+
+// converters registered converters by types.
+var converters sync.Map
+
+// RegisterConverter registers function converting string into a field of typ,
+// it is consulted by SetString before default conversions, e.g. for types not implementing encoding.TextUnmarshaler.
+//
+// Registering nil function removes converter of typ. It is safe for concurrent use.
+func RegisterConverter(typ reflect.Type, fn ConverterFunc) {
+	if fn == nil {
+		converters.Delete(typ)
+		return
+	}
+
+	converters.Store(typ, fn)
+}
+
+// lookupConverter returns registered converter of typ.
+func lookupConverter(typ reflect.Type) (ConverterFunc, bool) {
+	fn, ok := converters.Load(typ)
+	if !ok {
+		return nil, false
+	}
+
+	return fn.(ConverterFunc), true
+}
+
+// RegisterParseFunc registers function constructing T from string as converter of T,
+// e.g. `value.RegisterParseFunc(netip.ParseAddr)`.
+//
+// Converter of T is used for fields of T, pointers to T are allocated by Set.
+func RegisterParseFunc[T any](parse func(string) (T, error)) {
+	RegisterConverter(reflect.TypeOf((*T)(nil)).Elem(), func(field reflect.Value, raw string) error {
+		parsed, err := parse(raw)
+		if err != nil {
+			return err
+		}
+
+		field.Set(reflect.ValueOf(&parsed).Elem())
+		return nil
+	})
+}
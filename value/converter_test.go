@@ -0,0 +1,148 @@
+package value
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+type countryCode string
+
+type money struct {
+	Amount   string
+	Currency string
+}
+
+func TestRegisterConverter(t *testing.T) {
+	errInvalidMoney := errors.New("invalid money")
+
+	RegisterConverter(reflect.TypeOf(countryCode("")), func(field reflect.Value, raw string) error {
+		field.SetString(strings.ToUpper(raw))
+		return nil
+	})
+	RegisterConverter(reflect.TypeOf(money{}), func(field reflect.Value, raw string) error {
+		amount, currency, ok := strings.Cut(raw, " ")
+		if !ok {
+			return errInvalidMoney
+		}
+
+		field.Set(reflect.ValueOf(money{Amount: amount, Currency: currency}))
+		return nil
+	})
+	t.Cleanup(func() {
+		RegisterConverter(reflect.TypeOf(countryCode("")), nil)
+		RegisterConverter(reflect.TypeOf(money{}), nil)
+	})
+
+	var data struct {
+		Country countryCode
+		Price   money
+		Prices  []money
+		Ptr     *money
+	}
+
+	v := reflect.ValueOf(&data).Elem()
+
+	require.NoError(t, SetString(v.Field(0), "us"))
+	require.NoError(t, SetString(v.Field(1), "10.50 USD"))
+	require.NoError(t, SetSliceString(v.Field(2), []string{"1 EUR", "2 GBP"}))
+	require.NoError(t, Set(v.Field(3), "3 JPY"))
+	require.ErrorIs(t, SetString(v.Field(1), "10.50"), errInvalidMoney)
+
+	require.Equal(t, countryCode("US"), data.Country)
+	require.Equal(t, money{Amount: "10.50", Currency: "USD"}, data.Price)
+	require.Equal(t, []money{{Amount: "1", Currency: "EUR"}, {Amount: "2", Currency: "GBP"}}, data.Prices)
+	require.Equal(t, &money{Amount: "3", Currency: "JPY"}, data.Ptr)
+
+	RegisterConverter(reflect.TypeOf(money{}), nil)
+	require.ErrorIs(t, SetString(v.Field(1), "10.50 USD"), rerr.NotSupported)
+}
+
+func TestRegisterConverter_Concurrent(t *testing.T) {
+	typ := reflect.TypeOf(countryCode(""))
+	t.Cleanup(func() {
+		RegisterConverter(typ, nil)
+	})
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			RegisterConverter(typ, func(field reflect.Value, raw string) error {
+				field.SetString(raw)
+				return nil
+			})
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			var c countryCode
+			require.NoError(t, SetString(reflect.ValueOf(&c).Elem(), strings.Repeat("a", i)))
+		}()
+	}
+
+	wg.Wait()
+}
+
+type weekday int
+
+func parseWeekday(str string) (weekday, error) {
+	for i, name := range []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"} {
+		if name == str {
+			return weekday(i), nil
+		}
+	}
+
+	return 0, errors.New("unknown weekday")
+}
+
+type tag interface {
+	Name() string
+}
+
+type namedTag string
+
+func (t namedTag) Name() string {
+	return string(t)
+}
+
+func TestRegisterParseFunc(t *testing.T) {
+	RegisterParseFunc(parseWeekday)
+	RegisterParseFunc(func(str string) (tag, error) {
+		return namedTag(str), nil
+	})
+	t.Cleanup(func() {
+		RegisterConverter(reflect.TypeOf(weekday(0)), nil)
+		RegisterConverter(reflect.TypeOf((*tag)(nil)).Elem(), nil)
+	})
+
+	var data struct {
+		Day  weekday
+		Days []weekday
+		Ptr  *weekday
+		Tag  tag
+	}
+
+	v := reflect.ValueOf(&data).Elem()
+
+	require.NoError(t, SetString(v.Field(0), "tue"))
+	require.NoError(t, Set(v.Field(1), []string{"mon", "fri"}))
+	require.NoError(t, Set(v.Field(2), "sat"))
+	require.NoError(t, SetString(v.Field(3), "go"))
+	require.Error(t, SetString(v.Field(0), "tuesday"))
+
+	require.Equal(t, weekday(2), data.Day)
+	require.Equal(t, []weekday{1, 5}, data.Days)
+	require.NotNil(t, data.Ptr)
+	require.Equal(t, weekday(6), *data.Ptr)
+	require.Equal(t, namedTag("go"), data.Tag)
+}
@@ -0,0 +1,77 @@
+package value
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+const (
+	// EncodingHex hex encoding.
+	EncodingHex = "hex"
+	// EncodingBase64 standard base64 encoding.
+	EncodingBase64 = "base64"
+)
+
+// SetEncodedString decodes string with encoding and sets result into a field.
+//
+// Field must be a slice of bytes.
+func SetEncodedString(field reflect.Value, str, encoding string) error {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+
+		field = field.Elem()
+	}
+
+	if field.Kind() != reflect.Slice || field.Type().Elem().Kind() != reflect.Uint8 {
+		return errors.Wrapf(rerr.NotSupported, "`%s` encoding into `%s`", encoding, field.Type())
+	}
+
+	var (
+		decoded []byte
+		err     error
+	)
+
+	switch encoding {
+	case EncodingHex:
+		decoded, err = hex.DecodeString(str)
+	case EncodingBase64:
+		decoded, err = base64.StdEncoding.DecodeString(str)
+	default:
+		return errors.Wrapf(rerr.NotSupported, "`%s` encoding", encoding)
+	}
+
+	if err != nil {
+		return errors.WithMessagef(err, "decode `%s` string", encoding)
+	}
+
+	field.SetBytes(decoded)
+	return nil
+}
+
+// SetBase64JSONString decodes base64url string, padded or not, and unmarshals json result into a field.
+//
+// Invalid base64 is rerr.InvalidFormat error, invalid json is rerr.DecodeError.
+func SetBase64JSONString(field reflect.Value, str string) error {
+	if !field.CanAddr() {
+		return errors.WithStack(rerr.NotSupported)
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(str, "="))
+	if err != nil {
+		return errors.Wrapf(rerr.InvalidFormat, "decode base64url string: %v", err)
+	}
+
+	if err := json.Unmarshal(decoded, field.Addr().Interface()); err != nil {
+		return errors.WithStack(rerr.DecodeError{Err: err})
+	}
+
+	return nil
+}
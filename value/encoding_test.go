@@ -0,0 +1,149 @@
+package value
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetEncodedString(t *testing.T) {
+	tests := []struct {
+		name     string
+		str      string
+		encoding string
+		want     []byte
+		wantErr  bool
+	}{
+		{
+			name:     "hex",
+			str:      "deadbeef",
+			encoding: EncodingHex,
+			want:     []byte{0xde, 0xad, 0xbe, 0xef},
+		},
+		{
+			name:     "uppercase hex",
+			str:      "DEADBEEF",
+			encoding: EncodingHex,
+			want:     []byte{0xde, 0xad, 0xbe, 0xef},
+		},
+		{
+			name:     "odd-length hex",
+			str:      "deadbee",
+			encoding: EncodingHex,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid hex",
+			str:      "zz",
+			encoding: EncodingHex,
+			wantErr:  true,
+		},
+		{
+			name:     "base64",
+			str:      "aGVsbG8=",
+			encoding: EncodingBase64,
+			want:     []byte("hello"),
+		},
+		{
+			name:     "unknown encoding",
+			str:      "hello",
+			encoding: "rot13",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var testStruct struct {
+				B  []byte
+				BP *[]byte
+			}
+
+			v := reflect.Indirect(reflect.ValueOf(&testStruct))
+
+			err := SetEncodedString(v.Field(0), tt.str, tt.encoding)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, testStruct.B)
+
+			err = SetEncodedString(v.Field(1), tt.str, tt.encoding)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, *testStruct.BP)
+		})
+	}
+
+	t.Run("not bytes field", func(t *testing.T) {
+		var testStruct struct {
+			S string
+		}
+
+		err := SetEncodedString(reflect.ValueOf(&testStruct).Elem().Field(0), "deadbeef", EncodingHex)
+		require.True(t, errors.Is(err, rerr.NotSupported))
+	})
+}
+
+func TestSetBase64JSONString(t *testing.T) {
+	type State struct {
+		Redirect string `json:"redirect"`
+		Nonce    int    `json:"nonce"`
+	}
+
+	tests := []struct {
+		name       string
+		str        string
+		want       State
+		wantErr    error
+		wantDecode bool
+	}{
+		{
+			name: "unpadded",
+			str:  base64.RawURLEncoding.EncodeToString([]byte(`{"redirect":"/a?b=c","nonce":42}`)),
+			want: State{Redirect: "/a?b=c", Nonce: 42},
+		},
+		{
+			name: "padded",
+			str:  base64.URLEncoding.EncodeToString([]byte(`{"nonce":1}`)),
+			want: State{Nonce: 1},
+		},
+		{
+			name:    "tampered base64",
+			str:     "eyJub25jZSI6MX0+/",
+			wantErr: rerr.InvalidFormat,
+		},
+		{
+			name:       "invalid json",
+			str:        base64.RawURLEncoding.EncodeToString([]byte(`{"nonce":`)),
+			wantDecode: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var state State
+			err := SetBase64JSONString(reflect.ValueOf(&state).Elem(), tt.str)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			if tt.wantDecode {
+				var decodeErr rerr.DecodeError
+				require.ErrorAs(t, err, &decodeErr)
+				require.NotErrorIs(t, err, rerr.InvalidFormat)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, state)
+		})
+	}
+
+	err := SetBase64JSONString(reflect.ValueOf(State{}), "e30")
+	require.ErrorIs(t, err, rerr.NotSupported)
+}
@@ -0,0 +1,113 @@
+package value
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+const (
+	// KeyValuePairSeparator separator of pairs of key-value string, e.g. `color=red,size=10`.
+	KeyValuePairSeparator = ","
+	// KeyValueSeparator separator of key and value of a pair, e.g. `color=red`.
+	KeyValueSeparator = "="
+
+	tagJSON = "json"
+)
+
+// SetKeyValueString fills struct field from comma-separated key-value string, e.g. `color=red,size=10`.
+//
+// See SetKeyValues for key matching rules.
+func SetKeyValueString(field reflect.Value, str string, strict bool) error {
+	return SetKeyValues(field, strings.Split(str, KeyValuePairSeparator), strict)
+}
+
+// SetKeyValues fills struct field from key-value pairs, e.g. `color=red`.
+//
+// Pair is split by the first `=`, so value may contain `=`, spaces around keys and values are trimmed
+// and empty pairs are skipped. Key matches json tag name of a field or its name case-insensitively.
+// Unknown keys are ignored, with strict they result in rerr.NotAllowed error.
+// Field is replaced only if all pairs are set, keys which are not present leave zero values.
+func SetKeyValues(field reflect.Value, pairs []string, strict bool) error {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+
+		field = field.Elem()
+	}
+
+	if field.Kind() != reflect.Struct {
+		return errors.Wrapf(rerr.NotSupported, "key-value pairs into `%s`", field.Type())
+	}
+
+	dst := reflect.New(field.Type()).Elem()
+	for _, pair := range pairs {
+		pair = strings.TrimSpace(pair)
+		if len(pair) == 0 {
+			continue
+		}
+
+		key, v, found := strings.Cut(pair, KeyValueSeparator)
+		if !found {
+			return errors.Wrapf(rerr.InvalidFormat, "key-value pair `%s`", pair)
+		}
+
+		key = strings.TrimSpace(key)
+
+		i, ok := keyFieldIndex(dst.Type(), key)
+		if !ok {
+			if strict {
+				return errors.Wrapf(rerr.NotAllowed, "unknown key `%s`", key)
+			}
+
+			continue
+		}
+
+		if err := Set(dst.Field(i), strings.TrimSpace(v)); err != nil {
+			return errors.WithMessagef(err, "set key `%s`", key)
+		}
+	}
+
+	field.Set(dst)
+	return nil
+}
+
+// keyFieldIndex returns index of exported field matching key by json tag name or by name case-insensitively.
+func keyFieldIndex(t reflect.Type, key string) (int, bool) {
+	index := -1
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get(tagJSON), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == key {
+			return i, true
+		}
+
+		if index < 0 && len(name) == 0 && strings.EqualFold(f.Name, key) {
+			index = i
+		}
+	}
+
+	return index, index >= 0
+}
+
+// isBytesUnmarshaler reports whether ptr implements an interface used by SetString for unknown types.
+func isBytesUnmarshaler(ptr any) bool {
+	switch ptr.(type) {
+	case encoding.TextUnmarshaler, encoding.BinaryUnmarshaler:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,122 @@
+package value
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+type keyValueTestData struct {
+	Color  string `json:"color"`
+	Size   int    `json:"size,omitempty"`
+	Weight *float64
+	Skip   string `json:"-"`
+	hidden string
+}
+
+func TestSetKeyValueString(t *testing.T) {
+	weight := 1.5
+
+	tests := []struct {
+		name    string
+		str     string
+		strict  bool
+		want    keyValueTestData
+		wantErr error
+	}{
+		{
+			name: "Two fields",
+			str:  "color=red,size=10",
+			want: keyValueTestData{Color: "red", Size: 10},
+		},
+		{
+			name: "Field name and spaces",
+			str:  " color = red , WEIGHT=1.5,",
+			want: keyValueTestData{Color: "red", Weight: &weight},
+		},
+		{
+			name: "Value with separator",
+			str:  "color=a=b",
+			want: keyValueTestData{Color: "a=b"},
+		},
+		{
+			name: "Unknown key",
+			str:  "color=red,shape=round",
+			want: keyValueTestData{Color: "red"},
+		},
+		{
+			name:    "Unknown key in strict mode",
+			str:     "color=red,shape=round",
+			strict:  true,
+			wantErr: rerr.NotAllowed,
+		},
+		{
+			name:    "Ignored field in strict mode",
+			str:     "skip=1",
+			strict:  true,
+			wantErr: rerr.NotAllowed,
+		},
+		{
+			name:    "Unexported field in strict mode",
+			str:     "hidden=1",
+			strict:  true,
+			wantErr: rerr.NotAllowed,
+		},
+		{
+			name:    "Pair without value",
+			str:     "color=red,size",
+			wantErr: rerr.InvalidFormat,
+		},
+		{
+			name:    "Invalid value",
+			str:     "size=big",
+			wantErr: strconv.ErrSyntax,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := keyValueTestData{Color: "blue"}
+
+			err := SetKeyValueString(reflect.ValueOf(&d).Elem(), tt.str, tt.strict)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Equal(t, keyValueTestData{Color: "blue"}, d, "field is unchanged on error")
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestSetKeyValues(t *testing.T) {
+	var data struct {
+		Ptr   *keyValueTestData
+		Int   int
+		URL   url.URL
+		Plain keyValueTestData
+	}
+
+	v := reflect.ValueOf(&data).Elem()
+
+	require.NoError(t, SetKeyValues(v.Field(0), []string{"color=red", "size=10"}, true))
+	require.Equal(t, &keyValueTestData{Color: "red", Size: 10}, data.Ptr)
+
+	require.ErrorIs(t, SetKeyValues(v.Field(1), []string{"color=red"}, false), rerr.NotSupported)
+
+	require.NoError(t, Set(v.Field(3), "color=green,size=2"))
+	require.Equal(t, keyValueTestData{Color: "green", Size: 2}, data.Plain)
+
+	require.NoError(t, Set(v.Field(3), []string{"color=black", "size=3"}))
+	require.Equal(t, keyValueTestData{Color: "black", Size: 3}, data.Plain)
+
+	require.NoError(t, Set(v.Field(2), "https://example.com?a=b"), "text unmarshaler takes precedence")
+	require.Equal(t, "example.com", data.URL.Host)
+
+	require.ErrorIs(t, Set(v.Field(3), "red"), rerr.NotSupported, "string without pairs")
+}
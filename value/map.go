@@ -0,0 +1,173 @@
+package value
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+// SetMapSliceString sets map of string slices into a field.
+//
+// The map is copied, so the field never aliases the source.
+func SetMapSliceString(field reflect.Value, m map[string][]string) error {
+	if field.Kind() == reflect.Pointer {
+		if !field.IsNil() {
+			return SetMapSliceString(field.Elem(), m)
+		}
+
+		ptr := reflect.New(field.Type().Elem())
+		if err := SetMapSliceString(ptr.Elem(), m); err != nil {
+			return err
+		}
+
+		field.Set(ptr)
+		return nil
+	}
+
+	fieldType := field.Type()
+	if field.Kind() != reflect.Map || fieldType.Key().Kind() != reflect.String {
+		return errors.WithStack(rerr.NotSupported)
+	}
+
+	elemType := fieldType.Elem()
+	switch elemType.Kind() {
+	case reflect.Slice:
+		if elemType.Elem().Kind() != reflect.String {
+			break
+		}
+
+		mv := reflect.MakeMapWithSize(fieldType, len(m))
+		for k, v := range m {
+			cp := make([]string, len(v))
+			copy(cp, v)
+
+			mv.SetMapIndex(reflect.ValueOf(k).Convert(fieldType.Key()), reflect.ValueOf(cp).Convert(elemType))
+		}
+
+		field.Set(mv)
+		return nil
+	case reflect.String:
+		mv := reflect.MakeMapWithSize(fieldType, len(m))
+		for k, v := range m {
+			if len(v) == 0 {
+				continue
+			}
+
+			mv.SetMapIndex(reflect.ValueOf(k).Convert(fieldType.Key()), reflect.ValueOf(v[0]).Convert(elemType))
+		}
+
+		field.Set(mv)
+		return nil
+	case reflect.Interface:
+		if elemType.NumMethod() != 0 {
+			break
+		}
+
+		mv := reflect.MakeMapWithSize(fieldType, len(m))
+		for k, v := range m {
+			var elem any
+			if len(v) == 1 {
+				elem = v[0]
+			} else {
+				cp := make([]string, len(v))
+				copy(cp, v)
+				elem = cp
+			}
+
+			mv.SetMapIndex(reflect.ValueOf(k).Convert(fieldType.Key()), reflect.ValueOf(elem))
+		}
+
+		field.Set(mv)
+		return nil
+	}
+
+	return errors.WithStack(rerr.NotSupported)
+}
+
+var typeMapAny = reflect.TypeOf(map[string]any(nil))
+
+// SetNestedMap sets nested map into a field, e.g. map[string]map[string]string.
+//
+// Values of the map are either nested maps of the same kind or leaf string slices,
+// leaf is set into slice element as is and into other elements as its first value,
+// empty interface element receives nested map as map[string]any and leaf as string or slice of strings.
+// The map is copied, so the field never aliases the source.
+func SetNestedMap(field reflect.Value, m map[string]any) error {
+	if field.Kind() == reflect.Pointer {
+		if !field.IsNil() {
+			return SetNestedMap(field.Elem(), m)
+		}
+
+		ptr := reflect.New(field.Type().Elem())
+		if err := SetNestedMap(ptr.Elem(), m); err != nil {
+			return err
+		}
+
+		field.Set(ptr)
+		return nil
+	}
+
+	fieldType := field.Type()
+	if field.Kind() != reflect.Map || fieldType.Key().Kind() != reflect.String {
+		return errors.WithStack(rerr.NotSupported)
+	}
+
+	mv := reflect.MakeMapWithSize(fieldType, len(m))
+	for k, v := range m {
+		elem := reflect.New(fieldType.Elem()).Elem()
+		if err := setNestedElem(elem, v); err != nil {
+			return errors.WithMessagef(err, "set value of key `%s`", k)
+		}
+
+		mv.SetMapIndex(reflect.ValueOf(k).Convert(fieldType.Key()), elem)
+	}
+
+	field.Set(mv)
+	return nil
+}
+
+// setNestedElem sets nested map or leaf string slice into element of a map.
+func setNestedElem(elem reflect.Value, v any) error {
+	isAny := elem.Kind() == reflect.Interface && elem.NumMethod() == 0
+
+	switch t := v.(type) {
+	case map[string]any:
+		if !isAny {
+			return SetNestedMap(elem, t)
+		}
+
+		nested := reflect.New(typeMapAny).Elem()
+		if err := SetNestedMap(nested, t); err != nil {
+			return err
+		}
+
+		elem.Set(nested)
+		return nil
+	case []string:
+		if len(t) == 0 {
+			return nil
+		}
+
+		if isAny && len(t) == 1 {
+			elem.Set(reflect.ValueOf(t[0]))
+			return nil
+		}
+
+		cp := make([]string, len(t))
+		copy(cp, t)
+
+		if isAny {
+			elem.Set(reflect.ValueOf(cp))
+			return nil
+		}
+
+		if elem.Kind() == reflect.Slice && elem.Type().Elem().Kind() != reflect.Uint8 {
+			return Set(elem, cp)
+		}
+
+		return Set(elem, t[0])
+	default:
+		return errors.Wrapf(rerr.NotSupported, "%T", v)
+	}
+}
@@ -0,0 +1,181 @@
+package value
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetMapSliceString(t *testing.T) {
+	m := map[string][]string{
+		"a": {"1"},
+		"b": {"2", "3"},
+	}
+
+	t.Run("map[string][]string", func(t *testing.T) {
+		var testStruct struct {
+			M map[string][]string
+		}
+
+		err := SetMapSliceString(reflect.ValueOf(&testStruct).Elem().Field(0), m)
+		require.NoError(t, err)
+		require.Equal(t, m, testStruct.M)
+
+		testStruct.M["b"][0] = "changed"
+		require.Equal(t, "2", m["b"][0], "source is not aliased")
+	})
+
+	t.Run("url.Values", func(t *testing.T) {
+		var testStruct struct {
+			M url.Values
+		}
+
+		err := SetMapSliceString(reflect.ValueOf(&testStruct).Elem().Field(0), m)
+		require.NoError(t, err)
+		require.Equal(t, url.Values(m), testStruct.M)
+	})
+
+	t.Run("map[string]string", func(t *testing.T) {
+		var testStruct struct {
+			M map[string]string
+		}
+
+		err := SetMapSliceString(reflect.ValueOf(&testStruct).Elem().Field(0), m)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"a": "1", "b": "2"}, testStruct.M)
+	})
+
+	t.Run("map[string]any", func(t *testing.T) {
+		var testStruct struct {
+			M map[string]any
+		}
+
+		err := SetMapSliceString(reflect.ValueOf(&testStruct).Elem().Field(0), m)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"a": "1", "b": []string{"2", "3"}}, testStruct.M)
+	})
+
+	t.Run("*map[string]string", func(t *testing.T) {
+		var testStruct struct {
+			M *map[string]string
+		}
+
+		err := SetMapSliceString(reflect.ValueOf(&testStruct).Elem().Field(0), m)
+		require.NoError(t, err)
+		require.NotNil(t, testStruct.M)
+		require.Equal(t, map[string]string{"a": "1", "b": "2"}, *testStruct.M)
+	})
+
+	t.Run("Set url.Values", func(t *testing.T) {
+		var testStruct struct {
+			M map[string][]string
+		}
+
+		err := Set(reflect.ValueOf(&testStruct).Elem().Field(0), url.Values(m))
+		require.NoError(t, err)
+		require.Equal(t, m, testStruct.M)
+	})
+
+	t.Run("Not supported", func(t *testing.T) {
+		var testStruct struct {
+			M map[string]int
+			S string
+		}
+
+		v := reflect.ValueOf(&testStruct).Elem()
+
+		for i := 0; i < v.NumField(); i++ {
+			err := SetMapSliceString(v.Field(i), m)
+			require.Error(t, err)
+		}
+	})
+}
+
+func TestSetNestedMap(t *testing.T) {
+	m := map[string]any{
+		"env": map[string]any{
+			"region": []string{"us"},
+			"zones":  []string{"a", "b"},
+		},
+	}
+
+	t.Run("map[string]map[string]string", func(t *testing.T) {
+		var testStruct struct {
+			M map[string]map[string]string
+		}
+
+		err := SetNestedMap(reflect.ValueOf(&testStruct).Elem().Field(0), m)
+		require.NoError(t, err)
+		require.Equal(t, map[string]map[string]string{"env": {"region": "us", "zones": "a"}}, testStruct.M)
+	})
+
+	t.Run("map[string]map[string][]string", func(t *testing.T) {
+		var testStruct struct {
+			M map[string]map[string][]string
+		}
+
+		err := SetNestedMap(reflect.ValueOf(&testStruct).Elem().Field(0), m)
+		require.NoError(t, err)
+		require.Equal(t, map[string]map[string][]string{"env": {"region": {"us"}, "zones": {"a", "b"}}}, testStruct.M)
+
+		testStruct.M["env"]["zones"][0] = "changed"
+		require.Equal(t, "a", m["env"].(map[string]any)["zones"].([]string)[0], "source is not aliased")
+	})
+
+	t.Run("map[string]map[string]int", func(t *testing.T) {
+		var testStruct struct {
+			M map[string]map[string]int
+		}
+
+		err := SetNestedMap(reflect.ValueOf(&testStruct).Elem().Field(0), map[string]any{
+			"limits": map[string]any{"cpu": []string{"2"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]map[string]int{"limits": {"cpu": 2}}, testStruct.M)
+	})
+
+	t.Run("map[string]any", func(t *testing.T) {
+		var testStruct struct {
+			M map[string]any
+		}
+
+		err := Set(reflect.ValueOf(&testStruct).Elem().Field(0), m)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{
+			"env": map[string]any{"region": "us", "zones": []string{"a", "b"}},
+		}, testStruct.M)
+	})
+
+	t.Run("*map[string]map[string]string", func(t *testing.T) {
+		var testStruct struct {
+			M *map[string]map[string]string
+		}
+
+		err := SetNestedMap(reflect.ValueOf(&testStruct).Elem().Field(0), m)
+		require.NoError(t, err)
+		require.NotNil(t, testStruct.M)
+		require.Equal(t, "us", (*testStruct.M)["env"]["region"])
+	})
+
+	t.Run("leaf into map", func(t *testing.T) {
+		var testStruct struct {
+			M map[string]map[string]string
+		}
+
+		err := SetNestedMap(reflect.ValueOf(&testStruct).Elem().Field(0), map[string]any{"env": []string{"x"}})
+		require.ErrorIs(t, err, rerr.NotSupported)
+		require.Nil(t, testStruct.M)
+	})
+
+	t.Run("not a map", func(t *testing.T) {
+		var testStruct struct {
+			M []string
+		}
+
+		err := SetNestedMap(reflect.ValueOf(&testStruct).Elem().Field(0), m)
+		require.ErrorIs(t, err, rerr.NotSupported)
+	})
+}
@@ -0,0 +1,70 @@
+package value
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+// groupSize amount of digits between grouping separators.
+const groupSize = 3
+
+// RelaxNumber strips grouping separators from human-entered number, e.g. `1_000` or `1,000.5`.
+//
+// Comma is assumed to be a grouping separator only, it must split integer part into groups of three digits,
+// so locale-specific decimal comma, e.g. `1,5`, results in rerr.InvalidFormat instead of a silently wrong number.
+// Underscore must be placed between digits, other characters are left for number conversion.
+func RelaxNumber(str string) (string, error) {
+	sign := ""
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		sign, str = str[:1], str[1:]
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(str, ".")
+	if strings.Contains(fracPart, ",") {
+		return "", errors.Wrapf(rerr.InvalidFormat, "grouping separator in fraction of `%s`", sign+str)
+	}
+
+	if strings.Contains(intPart, ",") {
+		groups := strings.Split(intPart, ",")
+		for i, g := range groups {
+			if !isDigits(g) || len(g) > groupSize || (i > 0 && len(g) != groupSize) {
+				return "", errors.Wrapf(rerr.InvalidFormat, "grouping of `%s`", sign+str)
+			}
+		}
+
+		intPart = strings.Join(groups, "")
+	}
+
+	if !validUnderscores(intPart) || !validUnderscores(fracPart) {
+		return "", errors.Wrapf(rerr.InvalidFormat, "underscores of `%s`", sign+str)
+	}
+
+	relaxed := sign + strings.ReplaceAll(intPart, "_", "")
+	if hasFrac {
+		relaxed += "." + strings.ReplaceAll(fracPart, "_", "")
+	}
+
+	return relaxed, nil
+}
+
+// validUnderscores reports whether every underscore of string is placed between digits.
+func validUnderscores(str string) bool {
+	for i := range len(str) {
+		if str[i] != '_' {
+			continue
+		}
+
+		if i == 0 || i == len(str)-1 || !isDigit(str[i-1]) || !isDigit(str[i+1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDigit reports whether byte is a decimal digit.
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -0,0 +1,95 @@
+package value
+
+import (
+	"testing"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelaxNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Underscores",
+			str:  "1_000",
+			want: "1000",
+		},
+		{
+			name: "Commas",
+			str:  "1,000,000",
+			want: "1000000",
+		},
+		{
+			name: "Commas with fraction",
+			str:  "-12,345.678_9",
+			want: "-12345.6789",
+		},
+		{
+			name: "Plain number",
+			str:  "+42.5",
+			want: "+42.5",
+		},
+		{
+			name: "Not a number is left for conversion",
+			str:  "abc",
+			want: "abc",
+		},
+		{
+			name:    "Malformed grouping",
+			str:     "1,00,0",
+			wantErr: true,
+		},
+		{
+			name:    "Decimal comma",
+			str:     "1,5",
+			wantErr: true,
+		},
+		{
+			name:    "Comma in fraction",
+			str:     "1.000,5",
+			wantErr: true,
+		},
+		{
+			name:    "Leading comma",
+			str:     ",100",
+			wantErr: true,
+		},
+		{
+			name:    "Long first group",
+			str:     "1000,000",
+			wantErr: true,
+		},
+		{
+			name:    "Leading underscore",
+			str:     "_100",
+			wantErr: true,
+		},
+		{
+			name:    "Double underscore",
+			str:     "1__000",
+			wantErr: true,
+		},
+		{
+			name:    "Underscore before dot",
+			str:     "1_.5",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RelaxNumber(tt.str)
+			if tt.wantErr {
+				require.ErrorIs(t, err, rerr.InvalidFormat)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package value
 
 import (
+	"encoding/json"
 	"reflect"
 	"strings"
 
@@ -8,10 +9,19 @@ import (
 	rerr "github.com/slipros/roamer/err"
 )
 
-var typeSliceOfAny = reflect.TypeOf([]any{})
+var (
+	typeSliceOfAny = reflect.TypeOf([]any{})
+	typeRawMessage = reflect.TypeOf(json.RawMessage{})
+)
 
 // SetSliceString sets slice of strings into a field.
+//
+// Strings are joined by comma for string and json.RawMessage fields.
 func SetSliceString(field reflect.Value, arr []string) error {
+	if field.Type() == typeRawMessage {
+		return SetString(field, strings.Join(arr, ","))
+	}
+
 	switch field.Kind() {
 	case reflect.String:
 		field.SetString(strings.Join(arr, ","))
@@ -32,7 +42,27 @@ func SetSliceString(field reflect.Value, arr []string) error {
 				field.Set(reflect.ValueOf(s))
 				return nil
 			}
+		default:
+			return setSliceElems(field, arr)
+		}
+	case reflect.Struct:
+		if len(arr) > 0 && strings.Contains(arr[0], KeyValueSeparator) &&
+			field.CanAddr() && !isBytesUnmarshaler(field.Addr().Interface()) {
+			// query value split by comma, e.g. `color=red,size=10`.
+			return SetKeyValues(field, arr, false)
 		}
+	case reflect.Pointer:
+		if !field.IsNil() {
+			return SetSliceString(field.Elem(), arr)
+		}
+
+		ptr := reflect.New(field.Type().Elem())
+		if err := SetSliceString(ptr.Elem(), arr); err != nil {
+			return err
+		}
+
+		field.Set(ptr)
+		return nil
 	case reflect.Interface:
 		// FIXME: make any assignable
 		//nolint:gocritic // no other way
@@ -45,3 +75,21 @@ func SetSliceString(field reflect.Value, arr []string) error {
 
 	return errors.WithStack(rerr.NotSupported)
 }
+
+// setSliceElems converts every string into an element of slice field,
+// slice type which is a text or binary unmarshaler unmarshals strings joined by comma instead.
+func setSliceElems(field reflect.Value, arr []string) error {
+	if field.CanAddr() && isBytesUnmarshaler(field.Addr().Interface()) {
+		return implementsBytesUnmarshaler(field.Addr().Interface(), strings.Join(arr, ","))
+	}
+
+	s := reflect.MakeSlice(field.Type(), len(arr), len(arr))
+	for i, v := range arr {
+		if err := SetString(s.Index(i), v); err != nil {
+			return errors.WithStack(rerr.SliceIterationError{Err: err, Index: i})
+		}
+	}
+
+	field.Set(s)
+	return nil
+}
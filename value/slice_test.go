@@ -1,11 +1,14 @@
 package value
 
 import (
+	"encoding/json"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
 	"github.com/stretchr/testify/require"
 )
 
@@ -80,6 +83,51 @@ func TestSetSliceString(t *testing.T) {
 		})
 	*/
 
+	t.Run("[]int", func(t *testing.T) {
+		var testStruct struct {
+			SL []int
+		}
+
+		err := SetSliceString(reflect.ValueOf(&testStruct).Elem().Field(0), []string{"1", "2"})
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, testStruct.SL)
+	})
+
+	t.Run("[]int invalid element", func(t *testing.T) {
+		var testStruct struct {
+			SL []int
+		}
+
+		err := SetSliceString(reflect.ValueOf(&testStruct).Elem().Field(0), []string{"1", "a"})
+		require.Error(t, err)
+
+		var iterErr rerr.SliceIterationError
+		require.True(t, errors.As(err, &iterErr))
+		require.Equal(t, 1, iterErr.Index)
+		require.Nil(t, testStruct.SL)
+	})
+
+	t.Run("*[]string", func(t *testing.T) {
+		var testStruct struct {
+			SL *[]string
+		}
+
+		err := SetSliceString(reflect.ValueOf(&testStruct).Elem().Field(0), []string{str, str})
+		require.NoError(t, err)
+		require.NotNil(t, testStruct.SL)
+		require.Equal(t, []string{str, str}, *testStruct.SL)
+	})
+
+	t.Run("*[]int invalid element", func(t *testing.T) {
+		var testStruct struct {
+			SL *[]int
+		}
+
+		err := SetSliceString(reflect.ValueOf(&testStruct).Elem().Field(0), []string{"a"})
+		require.Error(t, err)
+		require.Nil(t, testStruct.SL)
+	})
+
 	t.Run("[]error", func(t *testing.T) {
 		sl := []string{str, str}
 
@@ -130,3 +178,62 @@ func TestSetSliceString(t *testing.T) {
 		}
 	})
 }
+
+func TestSetSliceString_RawMessage(t *testing.T) {
+	var testStruct struct {
+		Raw   json.RawMessage
+		Bytes []byte
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(&testStruct))
+
+	err := SetSliceString(v.Field(0), []string{`{"a":1`, `"b":[1`, `2]}`})
+	require.NoError(t, err)
+	require.Equal(t, json.RawMessage(`{"a":1,"b":[1,2]}`), testStruct.Raw)
+
+	err = SetSliceString(v.Field(0), []string{`{"a":1`, `"b"}`})
+	require.ErrorIs(t, err, rerr.InvalidFormat)
+
+	err = SetString(v.Field(1), `{"a":1,"b":2}`)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"a":1,"b":2}`), testStruct.Bytes)
+}
+
+type semicolonIDs []int
+
+func (ids *semicolonIDs) UnmarshalText(text []byte) error {
+	*ids = nil
+	for _, part := range strings.FieldsFunc(string(text), func(r rune) bool { return r == ';' || r == ',' }) {
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return err
+		}
+
+		*ids = append(*ids, id)
+	}
+
+	return nil
+}
+
+func TestSetString_SliceUnmarshaler(t *testing.T) {
+	var testStruct struct {
+		IDs    semicolonIDs
+		Ptr    *semicolonIDs
+		Plain  []int
+		Joined semicolonIDs
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(&testStruct))
+
+	require.NoError(t, SetString(v.Field(0), "1;2;3"))
+	require.Equal(t, semicolonIDs{1, 2, 3}, testStruct.IDs)
+
+	require.NoError(t, Set(v.Field(1), "4;5"))
+	require.Equal(t, &semicolonIDs{4, 5}, testStruct.Ptr)
+
+	require.NoError(t, SetString(v.Field(2), "7"))
+	require.Equal(t, []int{7}, testStruct.Plain, "element-wise conversion without unmarshaler")
+
+	require.NoError(t, SetSliceString(v.Field(3), []string{"1;2", "3"}))
+	require.Equal(t, semicolonIDs{1, 2, 3}, testStruct.Joined)
+}
@@ -2,15 +2,28 @@ package value
 
 import (
 	"encoding"
+	"encoding/json"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	rerr "github.com/slipros/roamer/err"
 )
 
 // SetString sets string into a field.
+//
+// Converter registered by RegisterConverter for type of the field takes precedence over default conversions.
+// String of at least 10 digits is set into time.Time as unix timestamp, see UnixAuto,
+// shorter strings of digits, e.g. compact date `20240101`, are not guessed as timestamps.
+// String is set into []byte as raw bytes, json.RawMessage requires valid json.
+// String with key-value pairs, e.g. `color=red,size=10`, is set into struct which is not a text or binary unmarshaler,
+// see SetKeyValueString.
 func SetString(field reflect.Value, str string) error {
+	if convert, ok := lookupConverter(field.Type()); ok {
+		return convert(field, str)
+	}
+
 	switch field.Kind() {
 	case reflect.String:
 		field.SetString(str)
@@ -141,11 +154,28 @@ func SetString(field reflect.Value, str string) error {
 		elemKind := field.Type().Elem().Kind()
 		switch elemKind {
 		case reflect.Uint8:
+			if field.Type() == typeRawMessage && !json.Valid([]byte(str)) {
+				return errors.Wrapf(rerr.InvalidFormat, "`%s` is not json", str)
+			}
+
 			field.SetBytes([]byte(str))
 			return nil
 		case reflect.String:
 			field.Set(reflect.Append(field, reflect.ValueOf(str)))
 			return nil
+		case reflect.Interface:
+		default:
+			if field.CanAddr() && isBytesUnmarshaler(field.Addr().Interface()) {
+				return implementsBytesUnmarshaler(field.Addr().Interface(), str)
+			}
+
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := SetString(elem, str); err != nil {
+				return err
+			}
+
+			field.Set(reflect.Append(field, elem))
+			return nil
 		}
 	case reflect.Interface:
 		field.Set(reflect.ValueOf(str))
@@ -154,6 +184,10 @@ func SetString(field reflect.Value, str string) error {
 		return SetString(field.Elem(), str)
 	}
 
+	if field.Type() == typeTime && len(str) >= unixAutoMinDigits && isDigits(str) {
+		return SetUnixString(field, str, UnixAuto)
+	}
+
 	if !field.CanAddr() {
 		return errors.WithStack(rerr.NotSupported)
 	}
@@ -163,6 +197,11 @@ func SetString(field reflect.Value, str string) error {
 		return errors.WithStack(rerr.NotSupported)
 	}
 
+	if field.Kind() == reflect.Struct && strings.Contains(str, KeyValueSeparator) &&
+		!isBytesUnmarshaler(ptr.Interface()) {
+		return SetKeyValueString(field, str, false)
+	}
+
 	return implementsBytesUnmarshaler(ptr.Interface(), str)
 }
 
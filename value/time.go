@@ -0,0 +1,125 @@
+package value
+
+import (
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	rerr "github.com/slipros/roamer/err"
+)
+
+// UnixUnit unit of unix timestamp.
+type UnixUnit uint8
+
+const (
+	// UnixAuto unix timestamp in seconds if it has up to 10 digits, otherwise in milliseconds.
+	UnixAuto UnixUnit = iota
+	// UnixSeconds unix timestamp in seconds.
+	UnixSeconds
+	// UnixMilli unix timestamp in milliseconds.
+	UnixMilli
+)
+
+// unixSecondsMaxDigits max amount of digits of unix timestamp in seconds guessed by UnixAuto,
+// it covers seconds up to year 2286.
+const unixSecondsMaxDigits = 10
+
+// unixAutoMinDigits min amount of digits of string guessed as unix timestamp by SetString,
+// it covers seconds since September 2001.
+const unixAutoMinDigits = 10
+
+var typeTime = reflect.TypeOf(time.Time{})
+
+// ParseUnix parses unix timestamp of unit, e.g. `1700000000`, into UTC time.
+//
+// Timestamp must consist of digits only.
+func ParseUnix(str string, unit UnixUnit) (time.Time, error) {
+	if !isDigits(str) {
+		return time.Time{}, errors.Wrapf(rerr.NotSupported, "unix timestamp `%s`", str)
+	}
+
+	n, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.WithMessagef(err, "parse unix timestamp `%s`", str)
+	}
+
+	if unit == UnixAuto {
+		unit = UnixSeconds
+		if len(str) > unixSecondsMaxDigits {
+			unit = UnixMilli
+		}
+	}
+
+	if unit == UnixMilli {
+		return time.UnixMilli(n).UTC(), nil
+	}
+
+	return time.Unix(n, 0).UTC(), nil
+}
+
+// ParseRetryAfter parses value of Retry-After header, delay in seconds, e.g. `120`, is added to now,
+// HTTP-date, e.g. `Fri, 31 Dec 1999 23:59:59 GMT`, is returned as is.
+func ParseRetryAfter(str string, now time.Time) (time.Time, error) {
+	if isDigits(str) {
+		seconds, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return time.Time{}, errors.WithMessagef(err, "parse retry after `%s`", str)
+		}
+
+		if seconds > math.MaxInt64/int64(time.Second) {
+			return time.Time{}, errors.Wrapf(rerr.InvalidFormat, "retry after `%s` is too large", str)
+		}
+
+		return now.Add(time.Duration(seconds) * time.Second), nil
+	}
+
+	t, err := http.ParseTime(str)
+	if err != nil {
+		return time.Time{}, errors.WithMessagef(err, "parse retry after `%s`", str)
+	}
+
+	return t, nil
+}
+
+// SetUnixString parses unix timestamp of unit and sets result into a field.
+//
+// Field must be a time.Time.
+func SetUnixString(field reflect.Value, str string, unit UnixUnit) error {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+
+		field = field.Elem()
+	}
+
+	if field.Type() != typeTime {
+		return errors.Wrapf(rerr.NotSupported, "unix timestamp into `%s`", field.Type())
+	}
+
+	t, err := ParseUnix(str, unit)
+	if err != nil {
+		return err
+	}
+
+	field.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// isDigits reports whether string is not empty and consists of digits only.
+func isDigits(str string) bool {
+	if len(str) == 0 {
+		return false
+	}
+
+	for i := range len(str) {
+		if str[i] < '0' || str[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,189 @@
+package value
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	rerr "github.com/slipros/roamer/err"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnix(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		unit    UnixUnit
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "Seconds",
+			str:  "1700000000",
+			want: time.Unix(1700000000, 0).UTC(),
+		},
+		{
+			name: "Milliseconds",
+			str:  "1700000000123",
+			want: time.UnixMilli(1700000000123).UTC(),
+		},
+		{
+			name: "Max digits of seconds",
+			str:  "9999999999",
+			want: time.Unix(9999999999, 0).UTC(),
+		},
+		{
+			name: "Min digits of milliseconds",
+			str:  "10000000000",
+			want: time.UnixMilli(10000000000).UTC(),
+		},
+		{
+			name: "Short seconds",
+			str:  "0",
+			want: time.Unix(0, 0).UTC(),
+		},
+		{
+			name: "Forced seconds",
+			str:  "1700000000123",
+			unit: UnixSeconds,
+			want: time.Unix(1700000000123, 0).UTC(),
+		},
+		{
+			name: "Forced milliseconds",
+			str:  "1700000000",
+			unit: UnixMilli,
+			want: time.UnixMilli(1700000000).UTC(),
+		},
+		{
+			name:    "Not digits",
+			str:     "-1700000000",
+			wantErr: true,
+		},
+		{
+			name:    "Overflow",
+			str:     "99999999999999999999",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUnix(tt.str, tt.unit)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetString_Time(t *testing.T) {
+	var data struct {
+		Time time.Time
+		Ptr  *time.Time
+	}
+
+	v := reflect.ValueOf(&data).Elem()
+
+	require.NoError(t, SetString(v.Field(0), "1700000000"))
+	require.Equal(t, time.Unix(1700000000, 0).UTC(), data.Time)
+
+	require.NoError(t, SetString(v.Field(0), "1700000000123"))
+	require.Equal(t, time.UnixMilli(1700000000123).UTC(), data.Time)
+
+	require.NoError(t, SetString(v.Field(0), "2023-11-14T22:13:20Z"))
+	require.True(t, time.Unix(1700000000, 0).Equal(data.Time))
+
+	require.NoError(t, Set(v.Field(1), "1700000000"))
+	require.Equal(t, time.Unix(1700000000, 0).UTC(), *data.Ptr)
+
+	data.Time = time.Time{}
+	require.Error(t, SetString(v.Field(0), "20240101"), "compact date is not a unix timestamp")
+	require.Error(t, SetString(v.Field(0), "123"))
+	require.True(t, data.Time.IsZero())
+}
+
+func TestSetUnixString(t *testing.T) {
+	var data struct {
+		Time time.Time
+		Ptr  *time.Time
+		Int  int64
+	}
+
+	v := reflect.ValueOf(&data).Elem()
+
+	require.NoError(t, SetUnixString(v.Field(0), "1700000000", UnixMilli))
+	require.Equal(t, time.UnixMilli(1700000000).UTC(), data.Time)
+
+	require.NoError(t, SetUnixString(v.Field(1), "1700000000", UnixSeconds))
+	require.Equal(t, time.Unix(1700000000, 0).UTC(), *data.Ptr)
+
+	require.ErrorIs(t, SetUnixString(v.Field(2), "1700000000", UnixSeconds), rerr.NotSupported)
+	require.ErrorIs(t, SetUnixString(v.Field(0), "2023-11-14T22:13:20Z", UnixSeconds), rerr.NotSupported)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		str     string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "Seconds",
+			str:  "120",
+			want: now.Add(2 * time.Minute),
+		},
+		{
+			name: "Zero seconds",
+			str:  "0",
+			want: now,
+		},
+		{
+			name: "HTTP-date",
+			str:  "Fri, 31 Dec 1999 23:59:59 GMT",
+			want: time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name: "Obsolete HTTP-date",
+			str:  "Friday, 31-Dec-99 23:59:59 GMT",
+			want: time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name:    "Negative seconds",
+			str:     "-120",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid",
+			str:     "soon",
+			wantErr: true,
+		},
+		{
+			name:    "Overflow",
+			str:     "99999999999999999999",
+			wantErr: true,
+		},
+		{
+			name:    "Duration overflow",
+			str:     "9999999999999",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRetryAfter(tt.str, now)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
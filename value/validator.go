@@ -0,0 +1,55 @@
+package value
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// ValidatorFunc function validating value of registered type.
+type ValidatorFunc = func(v any) error
+
+var (
+	// validators registered validators by types.
+	validators sync.Map
+	// hasValidators reports whether any validator was registered, so lookups are skipped without them.
+	hasValidators atomic.Bool
+)
+
+// RegisterValidator registers function validating values of typ after they are set into a field,
+// e.g. `value.RegisterValidator(reflect.TypeOf(Email("")), validateEmail)`.
+//
+// Registering nil function removes validator of typ. It is safe for concurrent use.
+func RegisterValidator(typ reflect.Type, fn ValidatorFunc) {
+	if fn == nil {
+		validators.Delete(typ)
+		return
+	}
+
+	validators.Store(typ, fn)
+	hasValidators.Store(true)
+}
+
+// Validate calls validator registered for type of field, pointers are dereferenced.
+//
+// Empty values are not validated.
+func Validate(field reflect.Value) error {
+	if !hasValidators.Load() {
+		return nil
+	}
+
+	for field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return nil
+		}
+
+		field = field.Elem()
+	}
+
+	fn, ok := validators.Load(field.Type())
+	if !ok || field.IsZero() || !field.CanInterface() {
+		return nil
+	}
+
+	return fn.(ValidatorFunc)(field.Interface())
+}
@@ -0,0 +1,59 @@
+package value
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type email string
+
+var errInvalidEmail = errors.New("invalid email")
+
+func validateEmail(v any) error {
+	if !strings.Contains(string(v.(email)), "@") {
+		return errInvalidEmail
+	}
+
+	return nil
+}
+
+func TestValidate(t *testing.T) {
+	RegisterValidator(reflect.TypeOf(email("")), validateEmail)
+	t.Cleanup(func() {
+		RegisterValidator(reflect.TypeOf(email("")), nil)
+	})
+
+	valid, invalid := email("user@example.com"), email("user")
+	invalidPtr := &invalid
+
+	tests := []struct {
+		name    string
+		value   any
+		wantErr error
+	}{
+		{name: "valid", value: &valid},
+		{name: "invalid", value: &invalid, wantErr: errInvalidEmail},
+		{name: "invalid pointer", value: &invalidPtr, wantErr: errInvalidEmail},
+		{name: "empty", value: new(email)},
+		{name: "nil pointer", value: new(*email)},
+		{name: "not registered type", value: new(string)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(reflect.ValueOf(tt.value).Elem())
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+
+	RegisterValidator(reflect.TypeOf(email("")), nil)
+	require.NoError(t, Validate(reflect.ValueOf(&invalid).Elem()), "validator is removed")
+}
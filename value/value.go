@@ -3,18 +3,33 @@ package value
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
 	"reflect"
 
 	"github.com/pkg/errors"
 	rerr "github.com/slipros/roamer/err"
 )
 
+// MultiValue is a value which has both single string and slice of strings representations.
+type MultiValue interface {
+	// String returns single string representation of value.
+	String() string
+	// Strings returns slice of strings representation of value.
+	Strings() []string
+}
+
 // Set sets value into a field.
 func Set(field reflect.Value, value any) error {
 	if field.Kind() == reflect.Pointer && field.IsNil() {
-		// init ptr
-		field.Set(reflect.New(field.Type().Elem()))
-		field = reflect.Indirect(field)
+		// init ptr, the field is left nil if value can't be set.
+		ptr := reflect.New(field.Type().Elem())
+		if err := Set(ptr.Elem(), value); err != nil {
+			return err
+		}
+
+		field.Set(ptr)
+		return nil
 	}
 
 	switch t := value.(type) {
@@ -72,6 +87,20 @@ func Set(field reflect.Value, value any) error {
 		return SetFloat(field, *t)
 	case []string:
 		return SetSliceString(field, t)
+	case map[string][]string:
+		return SetMapSliceString(field, t)
+	case url.Values:
+		return SetMapSliceString(field, t)
+	case http.Header:
+		return SetMapSliceString(field, t)
+	case map[string]any:
+		return SetNestedMap(field, t)
+	case MultiValue:
+		if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+			return SetSliceString(field, t.Strings())
+		}
+
+		return SetString(field, t.String())
 	}
 
 	valueType := reflect.TypeOf(value)
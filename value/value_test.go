@@ -2,6 +2,7 @@ package value
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -15,7 +16,36 @@ func (i *implementsStringer) String() string {
 	return "hello"
 }
 
+type multiValue []string
+
+func (m multiValue) String() string {
+	return strings.Join(m, ", ")
+}
+
+func (m multiValue) Strings() []string {
+	return m
+}
+
 func TestSet(t *testing.T) {
+	t.Run("MultiValue", func(t *testing.T) {
+		var testStruct struct {
+			S   string
+			SL  []string
+			SLP *[]string
+		}
+
+		v := reflect.Indirect(reflect.ValueOf(&testStruct))
+
+		for i := 0; i < v.NumField(); i++ {
+			err := Set(v.Field(i), multiValue{"a", "b"})
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, "a, b", testStruct.S)
+		require.Equal(t, []string{"a", "b"}, testStruct.SL)
+		require.Equal(t, []string{"a", "b"}, *testStruct.SLP)
+	})
+
 	t.Run("String", func(t *testing.T) {
 		var testStruct struct {
 			S string